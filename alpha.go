@@ -0,0 +1,75 @@
+package matcher
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// matchAlpha local identifiers are matched by consistent renaming,
+// package-level, universe, imported package names, fields and methods are compared by name
+// e.g. func(a, b int) int { return a - b } matches func(x, y int) int { return x - y },
+// but not func(x, y int) int { return y - x }
+// Notice: the bijection is kept in MatchCtx across the whole match,
+// pattern ident without type info is keyed by name, so synthetic pattern works too
+func matchAlpha(x, y *ast.Ident, ctx *MatchCtx) bool {
+	if x.Name == "_" || y.Name == "_" {
+		return x.Name == y.Name
+	}
+	yObj := localObjectOf(ctx, y)
+	xObj := localObjectOf(ctx, x)
+	if yObj == nil {
+		return xObj == nil && x.Name == y.Name
+	}
+
+	var key any = xObj
+	if xObj == nil {
+		if ctx.Pkg != nil && ctx.TypeInfo() != nil && ctx.ObjectOf(x) != nil {
+			// non-local pattern ident never matches local ident
+			return false
+		}
+		key = x.Name
+	}
+
+	if obj, ok := ctx.alphaX[key]; ok {
+		return obj == yObj
+	}
+	if _, ok := ctx.alphaY[yObj]; ok {
+		return false
+	}
+	ctx.alphaX[key] = yObj
+	ctx.alphaY[yObj] = key
+	return true
+}
+
+// localObjectOf object declared in function scope, including labels
+func localObjectOf(ctx *MatchCtx, id *ast.Ident) types.Object {
+	if ctx.Pkg == nil || ctx.TypeInfo() == nil {
+		return nil
+	}
+	obj := ctx.ObjectOf(id)
+	switch obj.(type) {
+	case nil, *types.PkgName:
+		return nil
+	case *types.Label:
+		return obj
+	}
+	scope := obj.Parent()
+	if scope == nil || scope == types.Universe || obj.Pkg() == nil || scope == obj.Pkg().Scope() {
+		// fields, methods, universe and package-level objects
+		return nil
+	}
+	return obj
+}
+
+// copyAlpha interface key is not comparable for copyMap before go1.20
+func copyAlpha(xs map[any]types.Object, ys map[types.Object]any) (map[any]types.Object, map[types.Object]any) {
+	cpX := make(map[any]types.Object, len(xs))
+	for k, v := range xs {
+		cpX[k] = v
+	}
+	cpY := make(map[types.Object]any, len(ys))
+	for k, v := range ys {
+		cpY[k] = v
+	}
+	return cpX, cpY
+}
@@ -0,0 +1,139 @@
+package matcher_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/goghcrow/go-matcher"
+	. "github.com/goghcrow/go-matcher/combinator"
+	"golang.org/x/tools/go/packages"
+)
+
+const alphaSrc = `package p
+
+var g int
+
+func f1(a, b int) int { return a - b + g }
+func f2(x, y int) int { return x - y + g }
+func f3(x, y int) int { return x - y + len("") }
+func f4(x int) int    { return x - x + g }
+`
+
+func loadSrc(t *testing.T, src string) *packages.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Implicits:  map[ast.Node]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+		Scopes:     map[ast.Node]*types.Scope{},
+	}
+	pkg, err := (&types.Config{}).Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &packages.Package{
+		Name:      pkg.Name(),
+		PkgPath:   pkg.Path(),
+		Fset:      fset,
+		Syntax:    []*ast.File{f},
+		Types:     pkg,
+		TypesInfo: info,
+	}
+}
+
+func funcDecl(f *ast.File, name string) *ast.FuncDecl {
+	for _, d := range f.Decls {
+		if fd, ok := d.(*ast.FuncDecl); ok && fd.Name.Name == name {
+			return fd
+		}
+	}
+	return nil
+}
+
+func matchedFuncs(m *matcher.Matcher, pkg *packages.Package, ptn ast.Node) (names []string) {
+	f := pkg.Syntax[0]
+	m.Match(pkg, ptn, f, func(c *matcher.Cursor, ctx *matcher.MatchCtx) {
+		for _, it := range ctx.Stack {
+			if fd, ok := it.(*ast.FuncDecl); ok {
+				names = append(names, fd.Name.Name)
+				return
+			}
+		}
+	})
+	return names
+}
+
+func TestAlphaEquiv(t *testing.T) {
+	pkg := loadSrc(t, alphaSrc)
+	f1 := funcDecl(pkg.Syntax[0], "f1")
+	sub := func(x, y string) *ast.BinaryExpr {
+		return &ast.BinaryExpr{X: &ast.Ident{Name: x}, Op: token.SUB, Y: &ast.Ident{Name: y}}
+	}
+
+	for _, tt := range []struct {
+		name  string
+		alpha bool
+		ptn   func(m *matcher.Matcher) ast.Node
+		want  []string
+	}{
+		{
+			name: "strict",
+			ptn:  func(m *matcher.Matcher) ast.Node { return &ast.FuncDecl{Type: f1.Type, Body: f1.Body} },
+			want: []string{"f1"},
+		},
+		{
+			// f3 refers to builtin len instead of package-level g, f4 renames a and b to the same x
+			name:  "renamed",
+			alpha: true,
+			ptn:   func(m *matcher.Matcher) ast.Node { return &ast.FuncDecl{Type: f1.Type, Body: f1.Body} },
+			want:  []string{"f1", "f2"},
+		},
+		{
+			name:  "synthetic",
+			alpha: true,
+			ptn:   func(m *matcher.Matcher) ast.Node { return sub("u", "u") },
+			want:  []string{"f4"},
+		},
+		{
+			// renaming of failed u - u is rolled back
+			name:  "or",
+			alpha: true,
+			ptn: func(m *matcher.Matcher) ast.Node {
+				return OrEx[ExprPattern](m, sub("u", "u"), sub("w", "z"))
+			},
+			want: []string{"f1", "f2", "f3", "f4"},
+		},
+		{
+			name:  "not",
+			alpha: true,
+			ptn: func(m *matcher.Matcher) ast.Node {
+				return AndEx[ExprPattern](m, NotEx[ExprPattern](m, sub("u", "u")), sub("w", "z"))
+			},
+			want: []string{"f1", "f2", "f3"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := matcher.New()
+			m.AlphaEquiv = tt.alpha
+			got := matchedFuncs(m, pkg, tt.ptn(m))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package analysis
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"text/template"
+
+	"github.com/goghcrow/go-matcher"
+	goanalysis "golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+type (
+	Matcher  = matcher.Matcher
+	MatchCtx = matcher.MatchCtx
+	Cursor   = matcher.Cursor
+
+	// Rewrite returns the replacement of the matched node, nil means no fix
+	Rewrite func(*Cursor, *MatchCtx) ast.Node
+
+	Rule struct {
+		Pattern ast.Node
+		// Message is text/template, executed with binds of matched node,
+		// bound node is rendered as source code, e.g., "use {{.new}} instead of {{.old}}"
+		// bound value of MatchCtx.Vals is used as is, e.g., {{(.tag.Lookup "json").Name}}
+		Message  string
+		Category string
+		// Rewrite is optional, the result is reported as SuggestedFix
+		Rewrite Rewrite
+		// FixMessage is optional, template as Message
+		FixMessage string
+	}
+)
+
+type rule struct {
+	*Rule
+	msg *template.Template
+	fix *template.Template
+}
+
+// NewAnalyzer wraps patterns of rules made by m into analysis.Analyzer
+func NewAnalyzer(name, doc string, m *Matcher, rules ...*Rule) *goanalysis.Analyzer {
+	rs := make([]*rule, len(rules))
+	for i, r := range rules {
+		rs[i] = &rule{
+			Rule: r,
+			msg:  template.Must(template.New(name).Parse(r.Message)),
+		}
+		if r.FixMessage != "" {
+			rs[i].fix = template.Must(template.New(name).Parse(r.FixMessage))
+		}
+	}
+	return &goanalysis.Analyzer{
+		Name: name,
+		Doc:  doc,
+		Run: func(pass *goanalysis.Pass) (interface{}, error) {
+			return nil, run(pass, m, rs)
+		},
+	}
+}
+
+func run(pass *goanalysis.Pass, m *Matcher, rules []*rule) (err error) {
+	pkg := packageOf(pass)
+	for _, f := range pass.Files {
+		for _, r := range rules {
+			m.Match(pkg, r.Pattern, f, func(c *Cursor, ctx *MatchCtx) {
+				if err != nil {
+					return
+				}
+				var d goanalysis.Diagnostic
+				d, err = r.diagnostic(c, ctx)
+				if err == nil {
+					pass.Report(d)
+				}
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// packageOf makes MatchCtx.Pkg from pass
+func packageOf(pass *goanalysis.Pass) *packages.Package {
+	return &packages.Package{
+		ID:         pass.Pkg.Path(),
+		Name:       pass.Pkg.Name(),
+		PkgPath:    pass.Pkg.Path(),
+		Fset:       pass.Fset,
+		Syntax:     pass.Files,
+		Types:      pass.Pkg,
+		TypesInfo:  pass.TypesInfo,
+		TypesSizes: pass.TypesSizes,
+	}
+}
+
+func (r *rule) diagnostic(c *Cursor, ctx *MatchCtx) (goanalysis.Diagnostic, error) {
+	n := c.Node()
+	data := bindsData(ctx)
+
+	msg, err := execute(r.msg, data)
+	if err != nil {
+		return goanalysis.Diagnostic{}, err
+	}
+	d := goanalysis.Diagnostic{
+		Pos:      n.Pos(),
+		End:      n.End(),
+		Category: r.Category,
+		Message:  msg,
+	}
+
+	if r.Rewrite == nil {
+		return d, nil
+	}
+	replacement := r.Rewrite(c, ctx)
+	if replacement == nil {
+		return d, nil
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, ctx.Pkg.Fset, replacement); err != nil {
+		return goanalysis.Diagnostic{}, err
+	}
+	fixMsg := msg
+	if r.fix != nil {
+		if fixMsg, err = execute(r.fix, data); err != nil {
+			return goanalysis.Diagnostic{}, err
+		}
+	}
+	d.SuggestedFixes = []goanalysis.SuggestedFix{{
+		Message: fixMsg,
+		TextEdits: []goanalysis.TextEdit{{
+			Pos:     n.Pos(),
+			End:     n.End(),
+			NewText: buf.Bytes(),
+		}},
+	}}
+	return d, nil
+}
+
+// bindsData variable -> source code of bound node, or bound value of ctx.Vals, e.g., parsed struct tag
+func bindsData(ctx *MatchCtx) map[string]any {
+	data := map[string]any{}
+	for name, n := range ctx.Binds {
+		if matcher.IsNilNode(n) {
+			data[name] = ""
+		} else {
+			data[name] = matcher.ShowNode(ctx.Pkg.Fset, n)
+		}
+	}
+	for name, v := range ctx.Vals {
+		data[name] = v
+	}
+	return data
+}
+
+func execute(t *template.Template, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
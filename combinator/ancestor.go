@@ -0,0 +1,94 @@
+package combinator
+
+import (
+	"go/ast"
+
+	"github.com/goghcrow/go-matcher"
+)
+
+// Combinators of the parent chain of matched node, see MatchCtx.StackOf
+// ancestor is matched with its own parent chain, binds are kept if matched
+// Notice: the last of stack is the pseudo root of astutil.Apply, skipped
+// e.g. call inside for statement, including Init, Cond, Post
+// HasAncestor[CallExprPattern](m, &ast.ForStmt{}, 0)
+// e.g. call inside for body only
+// HasAncestor[CallExprPattern](m, InFieldOf[BlockStmtPattern](m, &ast.ForStmt{}, "Body"), 0)
+// e.g. expression used as if condition
+// InFieldOf[ExprPattern](m, &ast.IfStmt{}, "Cond")
+
+// HasParent the direct parent node matched ptn
+func HasParent[T Pattern](m *Matcher, ptn NodeOrPtn) T {
+	return HasAncestor[T](m, ptn, 1)
+}
+
+// HasAncestor any ancestor node within maxDepth matched ptn, maxDepth <= 0 means unlimited
+// the nearest matched ancestor is used
+func HasAncestor[T Pattern](m *Matcher, ptn NodeOrPtn, maxDepth int) T {
+	f := matcher.TryGetOrMkMatchFun[NodePattern](m, ptn)
+	return matcher.MkPattern[T](m, func(n ast.Node, ctx *MatchCtx) bool {
+		stack, names := ctx.StackOf(n)
+		for i := 1; i < len(stack)-1; i++ {
+			if maxDepth > 0 && i > maxDepth {
+				break
+			}
+			if matchAncestor(f, ctx, stack, names, i) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// NotInside no ancestor node matched ptn
+// e.g. not within a defer
+// NotInside[CallExprPattern](m, &ast.DeferStmt{})
+func NotInside[T Pattern](m *Matcher, ptn NodeOrPtn) T {
+	f := matcher.TryGetOrMkMatchFun[NodePattern](m, ptn)
+	return matcher.MkPattern[T](m, func(n ast.Node, ctx *MatchCtx) bool {
+		stack, names := ctx.StackOf(n)
+		if stack == nil {
+			return false
+		}
+		for i := 1; i < len(stack)-1; i++ {
+			if f(stack[i], withStack(ctx.Clone(), stack, names, i)) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// InField node is the field of its parent, field is the name of struct field, e.g., Cond, Lhs, Body
+func InField[T Pattern](m *Matcher, field string) T {
+	return InFieldOf[T](m, nil, field)
+}
+
+// InFieldOf node is the field of its parent, and the parent matched ptn, nil ptn means any parent
+func InFieldOf[T Pattern](m *Matcher, ptn NodeOrPtn, field string) T {
+	var f MatchFun
+	if ptn != nil {
+		f = matcher.TryGetOrMkMatchFun[NodePattern](m, ptn)
+	}
+	return matcher.MkPattern[T](m, func(n ast.Node, ctx *MatchCtx) bool {
+		stack, names := ctx.StackOf(n)
+		if len(stack) < 3 || names[0] != field {
+			return false
+		}
+		return f == nil || matchAncestor(f, ctx, stack, names, 1)
+	})
+}
+
+func matchAncestor(f MatchFun, ctx *MatchCtx, stack []ast.Node, names []string, i int) bool {
+	clone := withStack(ctx.Clone(), stack, names, i)
+	if f(stack[i], clone) {
+		ctx.Commit(clone)
+		return true
+	}
+	return false
+}
+
+func withStack(ctx *MatchCtx, stack []ast.Node, names []string, i int) *MatchCtx {
+	ctx.Stack = stack[i:]
+	ctx.Names = names[i:]
+	return ctx
+}
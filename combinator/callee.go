@@ -83,7 +83,7 @@ func FuncCalleeOf(m *Matcher, p Predicate[*types.Func]) CallExprPattern {
 // FuncCallee match pkg.fun exactly
 func FuncCallee(m *Matcher, funObj types.Object /*pkg,*/, fun string) CallExprPattern {
 	// qualified := pkg + "." + fun
-	// funObj := m.Lookup(qualified)
+	// funObj := l.MustLookup(qualified)
 	// assert(funObj != nil, qualified+" not found")
 
 	_, isFunc := funObj.Type().(*types.Signature)
@@ -109,7 +109,7 @@ func MethodCalleeOf(m *Matcher, p Predicate[*types.Func]) CallExprPattern {
 // addressable means whether the receiver is addressable
 func MethodCallee(m *Matcher, tyObj types.Object /*pkg, typ, */, method string, addressable bool) CallExprPattern {
 	// qualified := pkg + "." + typ
-	// tyObj := m.Lookup(qualified)
+	// tyObj := l.MustLookup(qualified)
 	// assert(tyObj != nil, qualified+" not found")
 
 	methodObj, _, _ := types.LookupFieldOrMethod(tyObj.Type(), addressable, tyObj.Pkg(), method)
@@ -143,7 +143,7 @@ func IfaceCalleeOf(m *Matcher, p Predicate[*types.Func]) CallExprPattern {
 // IfaceCallee match pkg.iface.method exactly
 func IfaceCallee(m *Matcher, ifaceObj types.Object /*pkg, iface, */, method string) CallExprPattern {
 	// qualified := pkg + "." + iface
-	// ifaceObj := m.Lookup(qualified)
+	// ifaceObj := l.MustLookup(qualified)
 	assert(ifaceObj != nil, ifaceObj.String()+" not found")
 
 	// types.Named -> types.Interface
@@ -0,0 +1,158 @@
+package combinator
+
+import (
+	"go/ast"
+	"go/types"
+	"path"
+	"strings"
+
+	"github.com/goghcrow/go-matcher"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// CalleeNamed a function or method call matched by qualified name, no need to load the callee package
+// wildcard * is supported, matched by path.Match, e.g.
+//
+//	pkg/path.Func
+//	pkg/path.Type.Method     receiver is T or *T
+//	(*pkg/path.Type).Method  receiver is *T
+//	(pkg/path.Type).Method   receiver is T
+//	net/http.*.Do
+//	(*gorm.io/gorm.DB).*
+//	github.com/*/log.*
+//
+// the receiver is the declared receiver of method or the type of receiver expr,
+// so promoted method of embedded field can be matched by both the embedded and the embedding type
+func CalleeNamed(m *Matcher, qualified string) CallExprPattern {
+	cands := parseCalleeName(qualified)
+	assert(len(cands) > 0, "invalid callee name: "+qualified)
+
+	return matcher.MkPattern[CallExprPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		call := n.(*ast.CallExpr)
+		if call == nil {
+			return false
+		}
+		f, _ := typeutil.Callee(ctx.TypeInfo(), call).(*types.Func)
+		if f == nil || f.Pkg() == nil {
+			return false
+		}
+
+		recv := f.Type().(*types.Signature).Recv()
+		if recv == nil {
+			for _, it := range cands {
+				if it.matchFunc(f) {
+					return true
+				}
+			}
+			return false
+		}
+
+		recvTypes := []types.Type{recv.Type()}
+		if sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr); ok {
+			if s := ctx.TypeInfo().Selections[sel]; s != nil {
+				recvTypes = append(recvTypes, s.Recv())
+			}
+		}
+		for _, it := range cands {
+			for _, recvTy := range recvTypes {
+				if it.matchMethod(f, recvTy) {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+type recvKind int
+
+const (
+	anyRecv recvKind = iota
+	ptrRecv
+	valRecv
+)
+
+type calleeName struct {
+	path string
+	typ  string // empty means function
+	name string
+	recv recvKind
+}
+
+func parseCalleeName(qualified string) (cands []calleeName) {
+	if strings.HasPrefix(qualified, "(") {
+		i := strings.Index(qualified, ").")
+		if i < 0 {
+			return nil
+		}
+		recv, name := qualified[1:i], qualified[i+2:]
+		kind := valRecv
+		if strings.HasPrefix(recv, "*") {
+			recv, kind = recv[1:], ptrRecv
+		}
+		j := strings.LastIndexByte(recv, '.')
+		if j < 0 || strings.LastIndexByte(recv, '/') > j {
+			return nil
+		}
+		cands = append(cands, calleeName{recv[:j], recv[j+1:], name, kind})
+	} else {
+		start := strings.LastIndexByte(qualified, '/') + 1
+		for i := start; i < len(qualified); i++ {
+			if qualified[i] != '.' {
+				continue
+			}
+			switch names := strings.Split(qualified[i+1:], "."); len(names) {
+			case 1:
+				cands = append(cands, calleeName{qualified[:i], "", names[0], anyRecv})
+			case 2:
+				cands = append(cands, calleeName{qualified[:i], names[0], names[1], anyRecv})
+			}
+		}
+	}
+	for _, it := range cands {
+		for _, ptn := range []string{it.path, it.typ, it.name} {
+			_, err := path.Match(ptn, "")
+			assert(err == nil, "invalid callee name: "+qualified)
+		}
+	}
+	return cands
+}
+
+func (c calleeName) matchFunc(f *types.Func) bool {
+	return c.typ == "" &&
+		globMatch(c.path, f.Pkg().Path()) &&
+		globMatch(c.name, f.Name())
+}
+
+func (c calleeName) matchMethod(f *types.Func, recv types.Type) bool {
+	if c.typ == "" {
+		return false
+	}
+	ptr, isPtr := recv.(*types.Pointer)
+	if isPtr {
+		recv = ptr.Elem()
+	}
+	if c.recv == ptrRecv && !isPtr || c.recv == valRecv && isPtr {
+		return false
+	}
+	named, _ := recv.(*types.Named)
+	if named == nil {
+		return false
+	}
+	tn := named.Origin().Obj()
+	if tn.Pkg() == nil {
+		return false
+	}
+	return globMatch(c.path, tn.Pkg().Path()) &&
+		globMatch(c.typ, tn.Name()) &&
+		globMatch(c.name, f.Name())
+}
+
+func globMatch(ptn, s string) bool {
+	ok, _ := path.Match(ptn, s)
+	return ok
+}
@@ -0,0 +1,268 @@
+package combinator
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/goghcrow/go-matcher"
+	"golang.org/x/tools/go/cfg"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// Statement relations on the control-flow graph of the body of EnclosingFunc
+// Notice: only simple statements are nodes of cfg, e.g., ExprStmt, AssignStmt, DeferStmt, ReturnStmt...
+// compound statements (if, for, switch, block...) are never matched
+// Notice: b is matched with binds of a, but binds of b are only kept by FollowedBy
+// Notice: exit means returning normally, paths ending in no-return call are aborted, not exits,
+// e.g., panic, os.Exit, log.Fatal, so mu.Lock(); if bad { panic(x) }; mu.Unlock() is AlwaysFollowedBy
+// Notice: cfg is cached in MatchCtx.Memo for the current Match call
+
+// FollowedBy statement matched a is followed by a statement matched b on some path
+func FollowedBy(m *Matcher, a, b NodeOrPtn) StmtPattern {
+	return mkCFGPattern(m, a, b, func(ctx *MatchCtx, g *funcCFG, loc nodeLoc, b MatchFun) bool {
+		found := false
+		g.forEachReachable(loc, func(n ast.Node) bool {
+			clone := ctx.Clone()
+			if b(n, clone) {
+				ctx.Commit(clone)
+				found = true
+			}
+			return found
+		})
+		return found
+	})
+}
+
+// AlwaysFollowedBy statement matched a is followed by a statement matched b on every path to exit
+// e.g., every mu.Lock() is followed by mu.Unlock()
+func AlwaysFollowedBy(m *Matcher, a, b NodeOrPtn) StmtPattern {
+	return mkCFGPattern(m, a, b, func(ctx *MatchCtx, g *funcCFG, loc nodeLoc, b MatchFun) bool {
+		match := g.matcher(ctx, b)
+		for _, n := range loc.block.Nodes[loc.index+1:] {
+			if match(n) {
+				return true
+			}
+		}
+		if len(loc.block.Succs) == 0 {
+			return g.aborted[loc.block]
+		}
+		escaped := g.escapedBlocks(match)
+		for _, succ := range loc.block.Succs {
+			if escaped[succ] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Dominates statement matched a dominates all statements matched b, at least one,
+// means every path from entry to the statement matched b passes through it
+func Dominates(m *Matcher, a, b NodeOrPtn) StmtPattern {
+	return mkCFGPattern(m, a, b, func(ctx *MatchCtx, g *funcCFG, loc nodeLoc, b MatchFun) bool {
+		match := g.matcher(ctx, b)
+
+		// reach from entry without passing through the statement
+		seen := map[*cfg.Block]bool{}
+		work := []*cfg.Block{g.Blocks[0]}
+		for len(work) > 0 {
+			blk := work[len(work)-1]
+			work = work[:len(work)-1]
+			if seen[blk] {
+				continue
+			}
+			seen[blk] = true
+			nodes := blk.Nodes
+			if blk == loc.block {
+				nodes = nodes[:loc.index]
+			}
+			for _, n := range nodes {
+				if match(n) {
+					return false
+				}
+			}
+			if blk != loc.block {
+				work = append(work, blk.Succs...)
+			}
+		}
+
+		found := false
+		g.forEachReachable(loc, func(n ast.Node) bool {
+			found = match(n)
+			return found
+		})
+		return found
+	})
+}
+
+// UnreachableStmt statement matched a is unreachable from entry, e.g., statements after return or panic
+func UnreachableStmt(m *Matcher, a NodeOrPtn) StmtPattern {
+	return mkCFGPattern(m, a, nil, func(ctx *MatchCtx, g *funcCFG, loc nodeLoc, _ MatchFun) bool {
+		return !loc.block.Live
+	})
+}
+
+type (
+	nodeLoc struct {
+		block *cfg.Block
+		index int
+	}
+	funcCFG struct {
+		*cfg.CFG
+		locs    map[ast.Node]nodeLoc
+		aborted map[*cfg.Block]bool // ends in no-return call
+	}
+	cfgKey      struct{ body *ast.BlockStmt }
+	cfgRelation func(ctx *MatchCtx, g *funcCFG, loc nodeLoc, b MatchFun) bool
+)
+
+func mkCFGPattern(m *Matcher, a, b NodeOrPtn, rel cfgRelation) StmtPattern {
+	aFun := matcher.TryGetOrMkMatchFun[StmtPattern](m, a)
+	var bFun MatchFun
+	if b != nil {
+		bFun = matcher.TryGetOrMkMatchFun[StmtPattern](m, b)
+	}
+	return matcher.MkPattern[StmtPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if matcher.IsNilNode(n) {
+			return false
+		}
+		if !aFun(n, ctx) {
+			return false
+		}
+		g := cfgOf(ctx)
+		if g == nil {
+			return false
+		}
+		loc, ok := g.locs[n]
+		if !ok {
+			return false
+		}
+		return rel(ctx, g, loc, bFun)
+	})
+}
+
+func cfgOf(ctx *MatchCtx) *funcCFG {
+	var body *ast.BlockStmt
+	switch f := ctx.EnclosingFunc().(type) {
+	case *ast.FuncDecl:
+		body = f.Body
+	case *ast.FuncLit:
+		body = f.Body
+	}
+	if body == nil {
+		return nil
+	}
+	return ctx.Memo(cfgKey{body}, func() any {
+		return newFuncCFG(ctx, body)
+	}).(*funcCFG)
+}
+
+func newFuncCFG(ctx *MatchCtx, body *ast.BlockStmt) *funcCFG {
+	g := &funcCFG{
+		CFG: cfg.New(body, func(call *ast.CallExpr) bool {
+			return mayReturn(ctx, call)
+		}),
+		locs:    map[ast.Node]nodeLoc{},
+		aborted: map[*cfg.Block]bool{},
+	}
+	for _, blk := range g.Blocks {
+		for i, n := range blk.Nodes {
+			g.locs[n] = nodeLoc{blk, i}
+		}
+		if len(blk.Succs) == 0 && len(blk.Nodes) > 0 {
+			if s, ok := blk.Nodes[len(blk.Nodes)-1].(*ast.ExprStmt); ok {
+				if call, ok := s.X.(*ast.CallExpr); ok && !mayReturn(ctx, call) {
+					g.aborted[blk] = true
+				}
+			}
+		}
+	}
+	return g
+}
+
+func mayReturn(ctx *MatchCtx, call *ast.CallExpr) bool {
+	switch f := typeutil.Callee(ctx.TypeInfo(), call).(type) {
+	case *types.Builtin:
+		return f.Name() != "panic"
+	case *types.Func:
+		switch f.FullName() {
+		case "os.Exit", "runtime.Goexit",
+			"log.Fatal", "log.Fatalf", "log.Fatalln",
+			"log.Panic", "log.Panicf", "log.Panicln",
+			"(*log.Logger).Fatal", "(*log.Logger).Fatalf", "(*log.Logger).Fatalln",
+			"(*log.Logger).Panic", "(*log.Logger).Panicf", "(*log.Logger).Panicln":
+			return false
+		}
+	}
+	return true
+}
+
+// matcher matches statements with binds of ctx, and without side effects
+func (g *funcCFG) matcher(ctx *MatchCtx, b MatchFun) func(ast.Node) bool {
+	return func(n ast.Node) bool {
+		s, ok := n.(ast.Stmt)
+		return ok && b(s, ctx.Clone())
+	}
+}
+
+// forEachReachable visits nodes after loc on all paths, until f returns true
+func (g *funcCFG) forEachReachable(loc nodeLoc, f func(ast.Node) bool) {
+	visit := func(nodes []ast.Node) bool {
+		for _, n := range nodes {
+			if _, ok := n.(ast.Stmt); ok && f(n) {
+				return true
+			}
+		}
+		return false
+	}
+	if visit(loc.block.Nodes[loc.index+1:]) {
+		return
+	}
+	seen := map[*cfg.Block]bool{}
+	work := append([]*cfg.Block{}, loc.block.Succs...)
+	for len(work) > 0 {
+		blk := work[len(work)-1]
+		work = work[:len(work)-1]
+		if seen[blk] {
+			continue
+		}
+		seen[blk] = true
+		if visit(blk.Nodes) {
+			return
+		}
+		work = append(work, blk.Succs...)
+	}
+}
+
+// escapedBlocks blocks from which some path reaches exit without passing through matched statement,
+// aborted blocks never escape
+func (g *funcCFG) escapedBlocks(match func(ast.Node) bool) map[*cfg.Block]bool {
+	hasMatched := map[*cfg.Block]bool{}
+	for _, blk := range g.Blocks {
+		for _, n := range blk.Nodes {
+			if match(n) {
+				hasMatched[blk] = true
+				break
+			}
+		}
+	}
+
+	escaped := map[*cfg.Block]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, blk := range g.Blocks {
+			if escaped[blk] || hasMatched[blk] {
+				continue
+			}
+			esc := len(blk.Succs) == 0 && !g.aborted[blk]
+			for _, succ := range blk.Succs {
+				esc = esc || escaped[succ]
+			}
+			if esc {
+				escaped[blk] = true
+				changed = true
+			}
+		}
+	}
+	return escaped
+}
@@ -0,0 +1,90 @@
+package combinator
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"sort"
+
+	"github.com/goghcrow/go-matcher"
+)
+
+// CompositeLitKeyed composite literal matched by keys regardless of order, typ nil means any type,
+// unkeyed struct literal is keyed by field names in declaration order, e.g., T{1, 2} is T{A: 1, B: 2}
+// key of map literal must be string constant, e.g., map[string]int{"a": 1}
+// allowExtra means the literal can have keys not in fields
+// Notice: typ is matched with syntax, type of nested literal may be elided, e.g., []T{{Name: "x"}}
+// e.g. T{Name: $x, ...}
+// CompositeLitKeyed(m, &ast.Ident{Name: "T"}, map[string]NodeOrPtn{"Name": Bind(m, "x", Wildcard[ExprPattern](m))}, true)
+func CompositeLitKeyed(m *Matcher, typ NodeOrPtn, fields map[string]NodeOrPtn, allowExtra bool) ExprPattern {
+	var typFun MatchFun
+	if typ != nil {
+		typFun = matcher.TryGetOrMkMatchFun[ExprPattern](m, typ)
+	}
+
+	type field struct {
+		key string
+		fun MatchFun
+	}
+	xs := make([]field, 0, len(fields))
+	for k, v := range fields {
+		xs = append(xs, field{k, matcher.TryGetOrMkMatchFun[ExprPattern](m, v)})
+	}
+	// deterministic order for binding
+	sort.Slice(xs, func(i, j int) bool { return xs[i].key < xs[j].key })
+
+	return matcher.MkPattern[ExprPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
+		lit, _ := n.(*ast.CompositeLit)
+		if lit == nil {
+			return false
+		}
+		elts, ok := keyedElts(ctx, lit)
+		if !ok {
+			return false
+		}
+		if !allowExtra && len(elts) != len(xs) {
+			return false
+		}
+
+		clone := ctx.Clone()
+		if typFun != nil && !typFun(lit.Type, clone) {
+			return false
+		}
+		for _, x := range xs {
+			elt, ok := elts[x.key]
+			if !ok || !x.fun(elt, clone) {
+				return false
+			}
+		}
+		ctx.Commit(clone)
+		return true
+	})
+}
+
+// keyedElts returns key -> value of elements, false if any key can't be named
+func keyedElts(ctx *MatchCtx, lit *ast.CompositeLit) (map[string]ast.Expr, bool) {
+	elts := map[string]ast.Expr{}
+	var st *types.Struct
+	if ty := ctx.TypeOf(lit); ty != nil {
+		st, _ = ty.Underlying().(*types.Struct)
+	}
+	for i, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			if st == nil || i >= st.NumFields() {
+				return nil, false
+			}
+			elts[st.Field(i).Name()] = elt
+			continue
+		}
+
+		if id, ok := kv.Key.(*ast.Ident); ok && st != nil {
+			elts[id.Name] = kv.Value
+		} else if v := ctx.TypeInfo().Types[kv.Key].Value; v != nil && v.Kind() == constant.String {
+			elts[constant.StringVal(v)] = kv.Value
+		} else {
+			return nil, false
+		}
+	}
+	return elts, true
+}
@@ -0,0 +1,129 @@
+package combinator
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"github.com/goghcrow/go-matcher"
+)
+
+// Notice: ConstXXX match any constant expression by folded value,
+// e.g., 1 << 10, time.Second * 5, typed constant, named const,
+// not only BasicLit like LitXXXOf
+
+func ConstValueOf[T TypingPattern](m *Matcher, p Predicate[constant.Value]) T {
+	return matcher.MkPattern[T](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		expr := n.(ast.Expr)
+		if expr == nil { // ast.Expr(nil)
+			return false
+		}
+		val := constValueOf(ctx, expr)
+		if val == nil {
+			return false
+		}
+		return p(ctx, val)
+	})
+}
+
+// ConstEquals val is constant.Value, or go value can be made by constant.Make, e.g., 42, "str", true
+func ConstEquals[T TypingPattern](m *Matcher, val any) T {
+	x := mkConst(val)
+	return ConstValueOf[T](m, func(ctx *MatchCtx, y constant.Value) bool {
+		return matcher.ConstEqual(x, y)
+	})
+}
+
+// ConstCompare constant op val, op is comparison operator, e.g., token.GTR
+func ConstCompare[T TypingPattern](m *Matcher, op token.Token, val any) T {
+	x := mkConst(val)
+	return ConstValueOf[T](m, func(ctx *MatchCtx, y constant.Value) bool {
+		if op == token.EQL {
+			return matcher.ConstEqual(y, x)
+		}
+		if op == token.NEQ {
+			return !matcher.ConstEqual(y, x)
+		}
+		return orderable(x, y) && constant.Compare(y, op, x)
+	})
+}
+
+// ConstIntRange integer constant in [lo, hi], float constant with integral value included, e.g., 1e3
+func ConstIntRange[T TypingPattern](m *Matcher, lo, hi int64) T {
+	return ConstValueOf[T](m, func(ctx *MatchCtx, v constant.Value) bool {
+		i := constant.ToInt(v)
+		if i.Kind() != constant.Int {
+			return false
+		}
+		return constant.Compare(i, token.GEQ, constant.MakeInt64(lo)) &&
+			constant.Compare(i, token.LEQ, constant.MakeInt64(hi))
+	})
+}
+
+func ConstStringOf[T TypingPattern](m *Matcher, p Predicate[string]) T {
+	return ConstValueOf[T](m, func(ctx *MatchCtx, v constant.Value) bool {
+		if v.Kind() != constant.String {
+			return false
+		}
+		return p(ctx, constant.StringVal(v))
+	})
+}
+
+func ConstStringHasPrefix[T TypingPattern](m *Matcher, prefix string) T {
+	return ConstStringOf[T](m, func(ctx *MatchCtx, s string) bool {
+		return strings.HasPrefix(s, prefix)
+	})
+}
+
+func ConstStringMatch[T TypingPattern](m *Matcher, reg *regexp.Regexp) T {
+	return ConstStringOf[T](m, func(ctx *MatchCtx, s string) bool {
+		return reg.MatchString(s)
+	})
+}
+
+func constValueOf(ctx *MatchCtx, expr ast.Expr) constant.Value {
+	if tv, ok := ctx.TypeInfo().Types[expr]; ok && tv.Value != nil {
+		return tv.Value
+	}
+	// name of const decl is not recorded in Types, e.g., const x = 1
+	if id, ok := expr.(*ast.Ident); ok {
+		if c, ok := ctx.ObjectOf(id).(*types.Const); ok {
+			return c.Val()
+		}
+	}
+	return nil
+}
+
+func mkConst(val any) constant.Value {
+	switch v := val.(type) {
+	case constant.Value:
+		return v
+	case int:
+		return constant.MakeInt64(int64(v))
+	case uint64:
+		return constant.MakeUint64(v)
+	case float32:
+		return constant.MakeFloat64(float64(v))
+	case float64:
+		return constant.MakeFloat64(v)
+	case rune:
+		return constant.MakeInt64(int64(v))
+	}
+	c := constant.Make(val)
+	assert(c.Kind() != constant.Unknown, "invalid constant")
+	return c
+}
+
+func orderable(x, y constant.Value) bool {
+	isNumeric := func(k constant.Kind) bool {
+		return k == constant.Int || k == constant.Float
+	}
+	return x.Kind() == y.Kind() && x.Kind() == constant.String ||
+		isNumeric(x.Kind()) && isNumeric(y.Kind())
+}
@@ -0,0 +1,111 @@
+package combinator
+
+import (
+	"go/ast"
+
+	"github.com/goghcrow/go-matcher"
+)
+
+// Counting and bounded search of subtree, the root is included like Any
+// subtrees out of bound are skipped while walking, not filtered after matching
+// binds of matched subtree are dropped, see AnyBind
+// e.g. function body contains more than 3 db calls not inside closures
+// &ast.FuncDecl{ Body: CountDescendantsNotCrossing[BlockStmtPattern](m, dbCall, &ast.FuncLit{}, gt3) }
+
+// CountDescendants the count of subtree nodes matched ptn
+func CountDescendants[T Pattern](m *Matcher, ptn NodeOrPtn, pred Predicate[int]) T {
+	return CountDescendantsNotCrossing[T](m, ptn, nil, pred)
+}
+
+// CountDescendantsNotCrossing likes CountDescendants, but nodes inside stop are skipped, nil stop means no limit
+func CountDescendantsNotCrossing[T Pattern](m *Matcher, ptn, stop NodeOrPtn, pred Predicate[int]) T {
+	stopFun := mkStopFun(m, stop)
+	return matcher.MkPattern[T](m, func(root ast.Node, ctx *MatchCtx) bool {
+		cnt := 0
+		ctx.MatchPruned(ptn, root, mkPruner(ctx, root, stopFun, -1), func(*matcher.Cursor, *MatchCtx) {
+			cnt++
+		})
+		return pred(ctx, cnt)
+	})
+}
+
+// AnyWithin any subtree node within depth matched ptn, depth 0 means root only
+func AnyWithin[T Pattern](m *Matcher, ptn NodeOrPtn, depth int) T {
+	return matcher.MkPattern[T](m, func(root ast.Node, ctx *MatchCtx) bool {
+		return anyPruned(ctx, ptn, root, mkPruner(ctx, root, nil, depth))
+	})
+}
+
+// AnyNotCrossing any subtree node matched ptn, but not inside the node matched stop
+// e.g. search the subtree but don't descend into nested closures
+// AnyNotCrossing[BlockStmtPattern](m, callPtn, &ast.FuncLit{})
+func AnyNotCrossing[T Pattern](m *Matcher, ptn, stop NodeOrPtn) T {
+	stopFun := mkStopFun(m, stop)
+	return matcher.MkPattern[T](m, func(root ast.Node, ctx *MatchCtx) bool {
+		return anyPruned(ctx, ptn, root, mkPruner(ctx, root, stopFun, -1))
+	})
+}
+
+// anyPruned the rest is skipped once matched
+func anyPruned(ctx *MatchCtx, ptn, root ast.Node, prune func(*matcher.Cursor) bool) bool {
+	matched := false
+	ctx.MatchPruned(ptn, root, func(c *matcher.Cursor) bool {
+		return matched || prune(c)
+	}, func(*matcher.Cursor, *MatchCtx) {
+		matched = true
+	})
+	return matched
+}
+
+func mkStopFun(m *Matcher, stop NodeOrPtn) MatchFun {
+	if stop == nil {
+		return nil
+	}
+	return matcher.TryGetOrMkMatchFun[NodePattern](m, stop)
+}
+
+// mkPruner prunes nodes deeper than depth from root, depth < 0 means unlimited,
+// and children of non-root node matched stop, nil stop means no stop,
+// stop is matched once for each node, with its parent chain to root and the pseudo root of astutil.Apply
+func mkPruner(ctx *MatchCtx, root ast.Node, stop MatchFun, depth int) func(*matcher.Cursor) bool {
+	type parent struct {
+		node  ast.Node
+		field string
+	}
+	var (
+		parents = map[ast.Node]parent{}
+		depths  = map[ast.Node]int{}
+		stopped = map[ast.Node]bool{}
+	)
+	isStop := func(n ast.Node) bool {
+		if v, ok := stopped[n]; ok {
+			return v
+		}
+		var (
+			stack []ast.Node
+			names []string
+		)
+		for it := n; it != nil; {
+			stack = append(stack, it)
+			p := parents[it]
+			it = p.node
+			names = append(names, p.field)
+		}
+		v := stop(n, withStack(ctx.Clone(), stack, names, 0))
+		stopped[n] = v
+		return v
+	}
+	return func(c *matcher.Cursor) bool {
+		n, p := c.Node(), c.Parent()
+		parents[n] = parent{p, c.Name()}
+		if n == root {
+			return false
+		}
+		d := depths[p] + 1
+		depths[n] = d
+		if depth >= 0 && d > depth {
+			return true
+		}
+		return stop != nil && p != root && isStop(p)
+	}
+}
@@ -45,6 +45,7 @@ type (
 	IdentsNode = matcher.IdentsNode
 	FieldsNode = matcher.FieldsNode
 	TokenNode  = matcher.TokenNode
+	NodesNode  = matcher.NodesNode
 )
 
 type (
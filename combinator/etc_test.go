@@ -0,0 +1,53 @@
+package combinator_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/goghcrow/go-matcher"
+	"golang.org/x/tools/go/packages"
+)
+
+// loadSrc type checks single file package p, without types info if noTypes
+func loadSrc(t *testing.T, src string, noTypes bool) *packages.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := &packages.Package{
+		Name:    f.Name.Name,
+		PkgPath: f.Name.Name,
+		Fset:    fset,
+		Syntax:  []*ast.File{f},
+	}
+	if noTypes {
+		return pkg
+	}
+	info := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Implicits:  map[ast.Node]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+		Scopes:     map[ast.Node]*types.Scope{},
+	}
+	pkg.Types, err = (&types.Config{}).Check(f.Name.Name, fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg.TypesInfo = info
+	return pkg
+}
+
+// matchedPos positions of all nodes matched ptn
+func matchedPos(m *matcher.Matcher, pkg *packages.Package, ptn ast.Node) (xs []string) {
+	m.Match(pkg, ptn, pkg.Syntax[0], func(c *matcher.Cursor, ctx *matcher.MatchCtx) {
+		xs = append(xs, pkg.Fset.Position(c.Node().Pos()).String())
+	})
+	return xs
+}
@@ -0,0 +1,106 @@
+package combinator
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/goghcrow/go-matcher"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// Instance of generic function or method
+type Instance struct {
+	Obj      types.Object // the generic origin, e.g., slices.Contains
+	TypeArgs []types.Type // explicit or inferred type arguments
+	Type     types.Type   // the instantiated type
+}
+
+// InstanceOf an instantiated generic function or method of generic type,
+// IndexExpr and IndexListExpr are unpacked, e.g.,
+// f, pkg.f, f[T], pkg.f[T1, T2], x.method
+func InstanceOf(m *Matcher, p Predicate[*Instance]) ExprPattern {
+	return matcher.MkPattern[ExprPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		expr := n.(ast.Expr)
+		if expr == nil { // ast.Expr(nil)
+			return false
+		}
+		inst := instanceOf(ctx, expr)
+		if inst == nil {
+			return false
+		}
+		return p(ctx, inst)
+	})
+}
+
+// InstanceCalleeOf a call of instantiated generic function or method of generic type
+// e.g., slices.Contains(xs, x), slices.Contains[[]int](xs, x), list.Push(x)
+func InstanceCalleeOf(m *Matcher, p Predicate[*Instance]) CallExprPattern {
+	return &ast.CallExpr{
+		Fun: InstanceOf(m, p),
+	}
+}
+
+func instanceOf(ctx *MatchCtx, expr ast.Expr) *Instance {
+	expr = astutil.Unparen(expr)
+	switch x := expr.(type) {
+	case *ast.IndexExpr:
+		expr = astutil.Unparen(x.X)
+	case *ast.IndexListExpr:
+		expr = astutil.Unparen(x.X)
+	}
+
+	var id *ast.Ident
+	switch x := expr.(type) {
+	case *ast.Ident:
+		id = x
+	case *ast.SelectorExpr:
+		id = x.Sel
+	default:
+		return nil
+	}
+
+	// Instances also records instantiated generic types, e.g., List[int]{}, List[int](x)
+	f, _ := ctx.ObjectOf(id).(*types.Func)
+	if f == nil {
+		return nil
+	}
+	obj := types.Object(f.Origin())
+
+	if inst, ok := ctx.TypeInfo().Instances[id]; ok {
+		return &Instance{
+			Obj:      obj,
+			TypeArgs: typeListOf(inst.TypeArgs),
+			Type:     inst.Type,
+		}
+	}
+
+	// method of instantiated generic type, e.g., List[int].Push
+	recv := f.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return nil
+	}
+	recvTy := recv.Type()
+	if ptr, ok := recvTy.(*types.Pointer); ok {
+		recvTy = ptr.Elem()
+	}
+	named, _ := recvTy.(*types.Named)
+	if named == nil || named.TypeArgs().Len() == 0 {
+		return nil
+	}
+	return &Instance{
+		Obj:      obj,
+		TypeArgs: typeListOf(named.TypeArgs()),
+		Type:     f.Type(),
+	}
+}
+
+func typeListOf(xs *types.TypeList) []types.Type {
+	ts := make([]types.Type, xs.Len())
+	for i := range ts {
+		ts[i] = xs.At(i)
+	}
+	return ts
+}
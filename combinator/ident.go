@@ -110,3 +110,83 @@ func IsBuiltin(m *Matcher) IdentPattern {
 		return ok
 	})
 }
+
+// IdentIsDef ident declares an object, or symbolic variable of type switch, or package name
+func IdentIsDef(m *Matcher) IdentPattern {
+	return IdentOf(m, func(ctx *MatchCtx, id *ast.Ident) bool {
+		_, ok := ctx.TypeInfo().Defs[id]
+		return ok
+	})
+}
+
+// IdentIsUse ident refers to an object
+func IdentIsUse(m *Matcher) IdentPattern {
+	return IdentOf(m, func(ctx *MatchCtx, id *ast.Ident) bool {
+		_, ok := ctx.TypeInfo().Uses[id]
+		return ok
+	})
+}
+
+// IdentIsImplicit ident is symbolic variable of type switch, e.g., x in switch x := y.(type),
+// which declares an implicit object for each case clause
+func IdentIsImplicit(m *Matcher) IdentPattern {
+	return IdentOf(m, func(ctx *MatchCtx, id *ast.Ident) bool {
+		if obj, ok := ctx.TypeInfo().Defs[id]; !ok || obj != nil {
+			return false
+		}
+		// id -> AssignStmt.Lhs -> TypeSwitchStmt.Assign
+		stack, _ := ctx.StackOf(id)
+		if len(stack) < 3 {
+			return false
+		}
+		assign, _ := stack[1].(*ast.AssignStmt)
+		ts, _ := stack[2].(*ast.TypeSwitchStmt)
+		if assign == nil || ts == nil || ts.Assign != assign || len(assign.Lhs) != 1 || assign.Lhs[0] != id {
+			return false
+		}
+		for _, clause := range ts.Body.List {
+			if obj := ctx.TypeInfo().Implicits[clause]; obj != nil && obj.Pos() == id.Pos() {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// IdentDefOf ident declares an object
+func IdentDefOf(m *Matcher, p Predicate[types.Object]) IdentPattern {
+	return IdentOf(m, func(ctx *MatchCtx, id *ast.Ident) bool {
+		obj := ctx.TypeInfo().Defs[id]
+		if obj == nil {
+			return false
+		}
+		return p(ctx, obj)
+	})
+}
+
+// IdentUseOf ident refers to an object
+func IdentUseOf(m *Matcher, p Predicate[types.Object]) IdentPattern {
+	return IdentOf(m, func(ctx *MatchCtx, id *ast.Ident) bool {
+		obj := ctx.TypeInfo().Uses[id]
+		if obj == nil {
+			return false
+		}
+		return p(ctx, obj)
+	})
+}
+
+// IdentRefersTo ident denotes the same object as the object bound to variable by BindObject,
+// or the node bound to variable, bound selector chain never matches, e.g., a.mu
+// e.g. Bind(m, "x", IdentIsDef(m)) ... IdentRefersTo(m, "x")
+func IdentRefersTo(m *Matcher, variable string) IdentPattern {
+	return IdentObjectOf(m, func(ctx *MatchCtx, obj types.Object) bool {
+		if n, ok := ctx.Binds[variable]; ok && len(objectPathOf(ctx, n)) > 1 {
+			return false
+		}
+		bound, ok := ctx.ObjBinds[variable]
+		if !ok {
+			bound = objectOfNode(ctx, ctx.Binds[variable])
+		}
+		return bound != nil && bound == obj
+	})
+}
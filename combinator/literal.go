@@ -5,7 +5,9 @@ import (
 	"go/constant"
 	"go/token"
 	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/goghcrow/go-matcher"
 )
@@ -15,6 +17,7 @@ import (
 // because zero Value is ambiguous, wildcard or zero value?
 
 // Notice: LitXXXOf returns ExprPattern, so the type of callback param is ast.Expr
+// Notice: LitXXXOf only match BasicLit, use ConstXXX for constant expression, e.g., 1 << 10
 
 func LitKindOf(m *Matcher, kind token.Token) ExprPattern {
 	return matcher.MkPattern[ExprPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
@@ -65,11 +68,92 @@ func LitStringValOf(m *Matcher, p Predicate[string]) ExprPattern {
 		if lit.Kind != token.STRING {
 			return false
 		}
-		val, _ := strconv.Unquote(lit.Value)
+		val, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return false
+		}
 		return p(ctx, val)
 	})
 }
 
+// StringLit string literal with unquoted value
+type StringLit struct {
+	Lit   *ast.BasicLit
+	Value string // unquoted value, empty if Err != nil
+	Raw   bool   // raw string literal, e.g., `a`
+	Err   error  // unquote error of malformed literal
+}
+
+// StringLitOf p is called even if unquote error, check StringLit.Err
+func StringLitOf(m *Matcher, p Predicate[*StringLit]) ExprPattern {
+	return matcher.MkPattern[ExprPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
+		lit, _ := n.(*ast.BasicLit)
+		if lit == nil {
+			return false
+		}
+		if lit.Kind != token.STRING {
+			return false
+		}
+		val, err := strconv.Unquote(lit.Value)
+		return p(ctx, &StringLit{
+			Lit:   lit,
+			Value: val,
+			Raw:   strings.HasPrefix(lit.Value, "`"),
+			Err:   err,
+		})
+	})
+}
+
+// StringLitValOf likes LitStringValOf, but the raw or interpreted form can be checked
+func StringLitValOf(m *Matcher, p Predicate[*StringLit]) ExprPattern {
+	return StringLitOf(m, func(ctx *MatchCtx, s *StringLit) bool {
+		return s.Err == nil && p(ctx, s)
+	})
+}
+
+func StringLitRaw(m *Matcher) ExprPattern {
+	return StringLitValOf(m, func(ctx *MatchCtx, s *StringLit) bool { return s.Raw })
+}
+
+func StringLitInterpreted(m *Matcher) ExprPattern {
+	return StringLitValOf(m, func(ctx *MatchCtx, s *StringLit) bool { return !s.Raw })
+}
+
+func StringLitMalformed(m *Matcher) ExprPattern {
+	return StringLitOf(m, func(ctx *MatchCtx, s *StringLit) bool { return s.Err != nil })
+}
+
+func StringLitHasPrefix(m *Matcher, prefix string) ExprPattern {
+	return StringLitValOf(m, func(ctx *MatchCtx, s *StringLit) bool {
+		return strings.HasPrefix(s.Value, prefix)
+	})
+}
+
+func StringLitHasSuffix(m *Matcher, suffix string) ExprPattern {
+	return StringLitValOf(m, func(ctx *MatchCtx, s *StringLit) bool {
+		return strings.HasSuffix(s.Value, suffix)
+	})
+}
+
+func StringLitContains(m *Matcher, substr string) ExprPattern {
+	return StringLitValOf(m, func(ctx *MatchCtx, s *StringLit) bool {
+		return strings.Contains(s.Value, substr)
+	})
+}
+
+func StringLitMatch(m *Matcher, reg *regexp.Regexp) ExprPattern {
+	return StringLitValOf(m, func(ctx *MatchCtx, s *StringLit) bool {
+		return reg.MatchString(s.Value)
+	})
+}
+
+// StringLitMultiLine value contains newline, e.g., raw literal spanning lines, "a\nb"
+func StringLitMultiLine(m *Matcher) ExprPattern {
+	return StringLitValOf(m, func(ctx *MatchCtx, s *StringLit) bool {
+		return strings.Contains(s.Value, "\n")
+	})
+}
+
 func TagOf(m *Matcher, p Predicate[*reflect.StructTag]) BasicLitPattern {
 	return matcher.MkPattern[BasicLitPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
 		if n == nil /*ast.Node(nil)*/ {
@@ -1,14 +1,93 @@
 package combinator
 
-import "go/types"
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/goghcrow/go-matcher"
+	"golang.org/x/tools/go/ast/astutil"
+)
 
 // ObjectOf
 // notice: can't be used for `f` or `a.b` in `f[T]()` `a.b[T]()`
 // unpacking index/indexList is needed firstly
-// please use XXX CalleeOf
+// please use XXX CalleeOf, or InstanceOf for generic instance
 func ObjectOf(m *Matcher, p Predicate[types.Object]) ExprPattern {
 	return OrEx[ExprPattern](m,
 		IdentObjectOf(m, p),
 		SelectorObjectOf(m, p),
 	)
 }
+
+// objectOfNode the object denoted or declared by node
+func objectOfNode(ctx *MatchCtx, n ast.Node) types.Object {
+	if matcher.IsNilNode(n) {
+		return nil
+	}
+	switch n := n.(type) {
+	case *ast.Ident:
+		return ctx.ObjectOf(n)
+	case *ast.ParenExpr:
+		return objectOfNode(ctx, astutil.Unparen(n))
+	case *ast.SelectorExpr:
+		return ctx.ObjectOf(n.Sel)
+	case *ast.FuncDecl:
+		return ctx.ObjectOf(n.Name)
+	case *ast.TypeSpec:
+		return ctx.ObjectOf(n.Name)
+	case *ast.Field:
+		if len(n.Names) == 1 {
+			return ctx.ObjectOf(n.Names[0])
+		}
+	case *ast.ValueSpec:
+		if len(n.Names) == 1 {
+			return ctx.ObjectOf(n.Names[0])
+		}
+	}
+	return nil
+}
+
+// objectPathOf objects along the selector chain of node, root first, e.g.,
+// [a, mu] for a.mu, [a, b, mu] for (*a.b).mu, [V] for pkg.V,
+// nil if the root is not an object, e.g., f().mu, xs[i].mu
+func objectPathOf(ctx *MatchCtx, n ast.Node) []types.Object {
+	if matcher.IsNilNode(n) {
+		return nil
+	}
+	switch n := n.(type) {
+	case *ast.ParenExpr:
+		return objectPathOf(ctx, astutil.Unparen(n))
+	case *ast.StarExpr:
+		return objectPathOf(ctx, n.X)
+	case *ast.SelectorExpr:
+		obj := ctx.ObjectOf(n.Sel)
+		if obj == nil {
+			return nil
+		}
+		if _, ok := ctx.TypeInfo().Selections[n]; !ok {
+			// qualified identifier
+			return []types.Object{obj}
+		}
+		root := objectPathOf(ctx, n.X)
+		if root == nil {
+			return nil
+		}
+		return append(root[:len(root):len(root)], obj)
+	}
+	if obj := objectOfNode(ctx, n); obj != nil {
+		return []types.Object{obj}
+	}
+	return nil
+}
+
+func samePath(xs, ys []types.Object) bool {
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if xs[i] != ys[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package combinator
 
 import (
 	"go/ast"
+	"go/types"
 
 	"github.com/goghcrow/go-matcher"
 )
@@ -29,3 +30,137 @@ func Any[T Pattern](m *Matcher, nodeOrPtn ast.Node) T {
 		return ctx.Matched(nodeOrPtn, root)
 	})
 }
+
+type BindMode int
+
+const (
+	BindFirst BindMode = iota // binds of the first matched subtree
+	BindLast                  // binds of the last matched subtree
+	BindAll                   // binds of all matched subtrees, node of variable is NodesNode
+)
+
+// AnyBind likes Any, but binds of matched subtree propagate out,
+// subtrees are matched in post-order, e.g., inner call before outer call
+// Notice: subtree is matched in new ctx, binds of outer ctx are invisible
+func AnyBind[T Pattern](m *Matcher, nodeOrPtn ast.Node, mode BindMode) T {
+	return matcher.MkPattern[T](m, func(root ast.Node, ctx *MatchCtx) bool {
+		var hits []*MatchCtx
+		ctx.Match(nodeOrPtn, root, func(c *matcher.Cursor, inner *MatchCtx) {
+			hits = append(hits, inner)
+		})
+		return mergeBinds(ctx, hits, mode)
+	})
+}
+
+func mergeBinds(ctx *MatchCtx, hits []*MatchCtx, mode BindMode) bool {
+	if len(hits) == 0 {
+		return false
+	}
+	switch mode {
+	case BindFirst:
+		hits = hits[:1]
+	case BindLast:
+		hits = hits[len(hits)-1:]
+	case BindAll:
+	default:
+		panic("unknown bind mode")
+	}
+
+	// non-node binds of the first hit win
+	for i := len(hits) - 1; i >= 0; i-- {
+		for k, v := range hits[i].TypeBinds {
+			ctx.TypeBinds[k] = v
+		}
+		for k, v := range hits[i].ObjBinds {
+			ctx.ObjBinds[k] = v
+		}
+		for k, v := range hits[i].ConstBinds {
+			ctx.ConstBinds[k] = v
+		}
+		for k, v := range hits[i].Vals {
+			ctx.Vals[k] = v
+		}
+	}
+
+	if mode != BindAll {
+		for k, v := range hits[0].Binds {
+			ctx.Binds[k] = v
+		}
+		return true
+	}
+	all := map[string]NodesNode{}
+	for _, hit := range hits {
+		for k, v := range hit.Binds {
+			all[k] = append(all[k], v)
+		}
+	}
+	for k, v := range all {
+		ctx.Binds[k] = v
+	}
+	return true
+}
+
+// BindObject match node and bind the object it denotes to variable,
+// later occurrence of variable must denote the same object, regardless of spelling, e.g., (mu) and mu
+// the first matched node is bound to variable as Bind
+// selector is compared along its receiver chain, e.g., a.mu and b.mu are different, a.mu and (*a).mu are same
+// e.g. mu.Lock(); ... mu.Unlock()
+// &ast.SelectorExpr{ X: BindObject(m, "mu", Wildcard[ExprPattern](m)), Sel: IdentNameOf(m, "Lock") }
+// &ast.SelectorExpr{ X: BindObject(m, "mu", Wildcard[ExprPattern](m)), Sel: IdentNameOf(m, "Unlock") }
+func BindObject[T TypingPattern](m *Matcher, variable string, ptn T) T {
+	return And(m, ptn, matcher.MkPattern[T](m, func(n ast.Node, ctx *MatchCtx) bool {
+		n = ctx.OriginOf(n)
+		path := objectPathOf(ctx, n)
+		if path == nil {
+			return false
+		}
+		obj := path[len(path)-1]
+		if bound, ok := ctx.ObjBinds[variable]; ok {
+			if bound != obj {
+				return false
+			}
+			if boundNode, ok := ctx.Binds[variable]; ok {
+				return samePath(objectPathOf(ctx, boundNode), path)
+			}
+			return true
+		}
+		ctx.ObjBinds[variable] = obj
+		ctx.Binds[variable] = n
+		return true
+	}))
+}
+
+// BindType match node and bind its type to variable,
+// later occurrence of variable must have the identical type, shared with TypeBind
+func BindType[T TypingPattern](m *Matcher, variable string, ptn T) T {
+	return And(m, ptn, TypeOf[T](m, func(ctx *MatchCtx, t types.Type) bool {
+		if t == nil {
+			return false
+		}
+		if bound, ok := ctx.TypeBinds[variable]; ok {
+			return types.Identical(bound, t)
+		}
+		ctx.TypeBinds[variable] = t
+		return true
+	}))
+}
+
+// BindConst match constant expr and bind its value to variable,
+// later occurrence of variable must have the equal value
+func BindConst[T TypingPattern](m *Matcher, variable string, ptn T) T {
+	return And(m, ptn, matcher.MkPattern[T](m, func(n ast.Node, ctx *MatchCtx) bool {
+		expr, _ := n.(ast.Expr)
+		if matcher.IsNilNode(expr) {
+			return false
+		}
+		val := ctx.TypeInfo().Types[expr].Value
+		if val == nil {
+			return false
+		}
+		if bound, ok := ctx.ConstBinds[variable]; ok {
+			return matcher.ConstEqual(bound, val)
+		}
+		ctx.ConstBinds[variable] = val
+		return true
+	}))
+}
@@ -14,10 +14,11 @@ type (
 )
 
 // Not a must be Pattern, can't be node literal, means TryGetMatchFun(m, a) != nil
+// renaming of Matcher.AlphaEquiv in a is rolled back if a fails, see MatchCtx.TryAlpha
 func Not[Ptn Pattern](m *Matcher, a Ptn) Ptn {
 	return combine1[Ptn](m, a, func(a MatchFun) MatchFun {
 		return func(n ast.Node, ctx *MatchCtx) bool {
-			return !a(n, ctx)
+			return !ctx.TryAlpha(func() bool { return a(n, ctx) })
 		}
 	})
 }
@@ -25,7 +26,7 @@ func Not[Ptn Pattern](m *Matcher, a Ptn) Ptn {
 func NotEx[T Pattern](m *Matcher, a NodeOrPtn) T {
 	return combineEx1[T](m, a, func(a MatchFun) MatchFun {
 		return func(n ast.Node, ctx *MatchCtx) bool {
-			return !a(n, ctx)
+			return !ctx.TryAlpha(func() bool { return a(n, ctx) })
 		}
 	})
 }
@@ -48,10 +49,11 @@ func AndEx[Ptn Pattern](m *Matcher, lhs, rhs NodeOrPtn) Ptn {
 }
 
 // Or lhs, rhs must be Pattern, can't be node literal, means TryGetMatchFun(m, l or r) != nil
+// renaming of Matcher.AlphaEquiv in failed lhs is rolled back, see MatchCtx.TryAlpha
 func Or[Ptn Pattern](m *Matcher, lhs, rhs Ptn) Ptn {
 	return combine[Ptn](m, lhs, rhs, func(lhs, rhs MatchFun) MatchFun {
 		return func(n ast.Node, ctx *MatchCtx) bool {
-			return lhs(n, ctx) || rhs(n, ctx)
+			return ctx.TryAlpha(func() bool { return lhs(n, ctx) }) || rhs(n, ctx)
 		}
 	})
 }
@@ -59,7 +61,7 @@ func Or[Ptn Pattern](m *Matcher, lhs, rhs Ptn) Ptn {
 func OrEx[Ptn Pattern](m *Matcher, lhs, rhs NodeOrPtn) Ptn {
 	return combineEx[Ptn](m, lhs, rhs, func(lhs, rhs MatchFun) MatchFun {
 		return func(n ast.Node, ctx *MatchCtx) bool {
-			return lhs(n, ctx) || rhs(n, ctx)
+			return ctx.TryAlpha(func() bool { return lhs(n, ctx) }) || rhs(n, ctx)
 		}
 	})
 }
@@ -0,0 +1,93 @@
+package combinator
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// Shadowing object declared by ident, and the object of the same name shadowed by it
+type Shadowing struct {
+	Obj      types.Object
+	Shadowed types.Object // declared in an enclosing scope, maybe universe
+}
+
+// IdentShadowsOf ident declares an object which shadows an object of the same name in an enclosing scope
+func IdentShadowsOf(m *Matcher, p Predicate[*Shadowing]) IdentPattern {
+	return IdentDefOf(m, func(ctx *MatchCtx, obj types.Object) bool {
+		if obj.Name() == "_" {
+			return false
+		}
+		// field, method, etc. have no parent scope
+		scope := obj.Parent()
+		if scope == nil || scope.Parent() == nil {
+			return false
+		}
+		_, shadowed := scope.Parent().LookupParent(obj.Name(), obj.Pos())
+		if shadowed == nil {
+			return false
+		}
+		return p(ctx, &Shadowing{Obj: obj, Shadowed: shadowed})
+	})
+}
+
+// IdentShadows ident declares an object which shadows a non-universe object
+func IdentShadows(m *Matcher) IdentPattern {
+	return IdentShadowsOf(m, func(ctx *MatchCtx, s *Shadowing) bool {
+		return s.Shadowed.Parent() != types.Universe
+	})
+}
+
+// IdentDeclaredInFunc the object denoted by ident is declared inside the current EnclosingFunc
+// e.g., local variables, params, results, receiver, labels, but not the name of func itself
+func IdentDeclaredInFunc(m *Matcher) IdentPattern {
+	return IdentObjectOf(m, func(ctx *MatchCtx, obj types.Object) bool {
+		return declaredIn(ctx, obj, ctx.EnclosingFunc())
+	})
+}
+
+// declaredIn obj is declared in the scope of func, or its nested scopes
+func declaredIn(ctx *MatchCtx, obj types.Object, fn ast.Node) bool {
+	var (
+		typ  *ast.FuncType
+		body *ast.BlockStmt
+		recv *ast.FieldList
+	)
+	switch fn := fn.(type) {
+	case *ast.FuncDecl:
+		typ, body, recv = fn.Type, fn.Body, fn.Recv
+	case *ast.FuncLit:
+		typ, body = fn.Type, fn.Body
+	default:
+		return false
+	}
+	if !obj.Pos().IsValid() {
+		return false
+	}
+
+	// label is declared in a separate label scope of func, located by position
+	if _, isLabel := obj.(*types.Label); !isLabel {
+		if fnScope := ctx.TypeInfo().Scopes[typ]; fnScope != nil {
+			// method, field, etc. have no parent scope
+			for s := obj.Parent(); s != nil; s = s.Parent() {
+				if s == fnScope {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	// from receiver or params to the end of body, the name of FuncDecl is excluded
+	start := typ.Params.Pos()
+	if typ.TypeParams != nil {
+		start = typ.TypeParams.Pos()
+	}
+	if recv != nil {
+		start = recv.Pos()
+	}
+	end := typ.End()
+	if body != nil {
+		end = body.End()
+	}
+	return start <= obj.Pos() && obj.Pos() < end
+}
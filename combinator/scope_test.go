@@ -0,0 +1,36 @@
+package combinator_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goghcrow/go-matcher"
+	. "github.com/goghcrow/go-matcher/combinator"
+)
+
+func TestIdentDeclaredInFunc(t *testing.T) {
+	pkg := loadSrc(t, `package p
+func fact(n int) int { x := n; L: for { break L }; if x <= 1 { return 1 }; return x * fact(n-1) }
+type T struct{}
+func (t T) m(a int) int { return a }
+`, false)
+	m := matcher.New()
+	got := matchedPos(m, pkg, IdentDeclaredInFunc(m))
+	// name fact at 2:6 and recursive call at 2:87 are excluded, so are int, T, method m
+	want := []string{
+		"p.go:2:11", // n
+		"p.go:2:24", // x
+		"p.go:2:29", // n
+		"p.go:2:32", // L
+		"p.go:2:47", // L
+		"p.go:2:55", // x
+		"p.go:2:83", // x
+		"p.go:2:92", // n
+		"p.go:4:7",  // t
+		"p.go:4:14", // a
+		"p.go:4:34", // a
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package combinator
+
+import (
+	"go/ast"
+
+	"github.com/goghcrow/go-matcher"
+)
+
+// Regex-style quantifiers, only work as elements of StmtsPattern or ExprsPattern
+// binds in repetitions of ZeroOrMore, OneOrMore, Repeat are collected as NodesNode
+// e.g. a block that is any number of logging calls followed by a return
+// &ast.BlockStmt{ List: []ast.Stmt{ ZeroOrMore[StmtPattern](m, logStmt), &ast.ReturnStmt{} } }
+
+type SeqElemPattern = matcher.SeqElemPattern
+
+// Optional p?
+func Optional[T SeqElemPattern](m *Matcher, p ast.Node) T {
+	return Repeat[T](m, p, 0, 1)
+}
+
+// ZeroOrMore p*
+func ZeroOrMore[T SeqElemPattern](m *Matcher, p ast.Node) T {
+	return Repeat[T](m, p, 0, -1)
+}
+
+// OneOrMore p+
+func OneOrMore[T SeqElemPattern](m *Matcher, p ast.Node) T {
+	return Repeat[T](m, p, 1, -1)
+}
+
+// Repeat p{min,max}, max < 0 means unbounded
+func Repeat[T SeqElemPattern](m *Matcher, p ast.Node, min, max int) T {
+	return matcher.MkSeqPattern[T](m, &matcher.Quantifier{
+		Alts: [][]ast.Node{{p}},
+		Min:  min,
+		Max:  max,
+	})
+}
+
+// Alt seq1|seq2|..., alternatives are tried in order
+// e.g. Alt[StmtPattern](m, []ast.Node{ lockStmt, deferUnlockStmt }, []ast.Node{ lockStmt })
+func Alt[T SeqElemPattern](m *Matcher, seqs ...[]ast.Node) T {
+	return matcher.MkSeqPattern[T](m, &matcher.Quantifier{
+		Alts: seqs,
+		Min:  1,
+		Max:  1,
+	})
+}
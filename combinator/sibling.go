@@ -0,0 +1,101 @@
+package combinator
+
+import (
+	"go/ast"
+	"reflect"
+
+	"github.com/goghcrow/go-matcher"
+)
+
+// Combinators of siblings in the list field of parent, e.g., BlockStmt.List, CallExpr.Args
+// sibling is matched with its own parent chain, binds are kept if matched
+// e.g. rows, err := db.Query(); not followed by defer rows.Close()
+// And(m, queryStmt, Not(m, FollowingSiblings[StmtPattern](m, deferCloseStmt)))
+
+// NextSibling the next sibling matched ptn
+func NextSibling[T Pattern](m *Matcher, ptn NodeOrPtn) T {
+	return mkSiblingPattern[T](m, ptn, func(idx, n int) (int, int) { return idx + 1, idx + 2 })
+}
+
+// PrevSibling the previous sibling matched ptn
+func PrevSibling[T Pattern](m *Matcher, ptn NodeOrPtn) T {
+	return mkSiblingPattern[T](m, ptn, func(idx, n int) (int, int) { return idx - 1, idx })
+}
+
+// FollowingSiblings any following sibling matched ptn, the nearest one is used
+func FollowingSiblings[T Pattern](m *Matcher, ptn NodeOrPtn) T {
+	return mkSiblingPattern[T](m, ptn, func(idx, n int) (int, int) { return idx + 1, n })
+}
+
+// PrecedingSiblings any preceding sibling matched ptn, the nearest one is used
+func PrecedingSiblings[T Pattern](m *Matcher, ptn NodeOrPtn) T {
+	return mkSiblingPattern[T](m, ptn, func(idx, n int) (int, int) { return 0, idx })
+}
+
+// mkSiblingPattern siblings in [from, to) are tried, nearest first
+func mkSiblingPattern[T Pattern](m *Matcher, ptn NodeOrPtn, rng func(idx, n int) (from, to int)) T {
+	f := matcher.TryGetOrMkMatchFun[NodePattern](m, ptn)
+	return matcher.MkPattern[T](m, func(n ast.Node, ctx *MatchCtx) bool {
+		stack, names := ctx.StackOf(n)
+		sibs, idx := siblingsOf(stack, names)
+		if idx < 0 {
+			return false
+		}
+		from, to := rng(idx, len(sibs))
+		if from < 0 {
+			from = 0
+		}
+		if to > len(sibs) {
+			to = len(sibs)
+		}
+		try := func(i int) bool {
+			clone := ctx.Clone()
+			clone.Stack = append([]ast.Node{sibs[i]}, stack[1:]...)
+			clone.Names = names
+			if f(sibs[i], clone) {
+				ctx.Commit(clone)
+				return true
+			}
+			return false
+		}
+		if from > idx {
+			for i := from; i < to; i++ {
+				if try(i) {
+					return true
+				}
+			}
+		} else {
+			for i := to - 1; i >= from; i-- {
+				if try(i) {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+// siblingsOf returns the list field of parent containing stack[0], and its index
+func siblingsOf(stack []ast.Node, names []string) ([]ast.Node, int) {
+	if len(stack) < 2 {
+		return nil, -1
+	}
+	parent := reflect.ValueOf(stack[1])
+	if parent.Kind() != reflect.Ptr || parent.Elem().Kind() != reflect.Struct {
+		return nil, -1
+	}
+	field := parent.Elem().FieldByName(names[0])
+	if !field.IsValid() || field.Kind() != reflect.Slice {
+		return nil, -1
+	}
+
+	idx := -1
+	sibs := make([]ast.Node, field.Len())
+	for i := range sibs {
+		sibs[i], _ = field.Index(i).Interface().(ast.Node)
+		if sibs[i] == stack[0] {
+			idx = i
+		}
+	}
+	return sibs, idx
+}
@@ -2,6 +2,7 @@ package combinator
 
 import (
 	"go/ast"
+	"go/constant"
 	"reflect"
 
 	"github.com/goghcrow/go-matcher"
@@ -23,6 +24,24 @@ func SliceContains[S SlicePattern](m *Matcher, p NodeOrPtn) S {
 	})
 }
 
+// SliceContainsBind likes SliceContains, but binds of matched subtree propagate out, see AnyBind
+func SliceContainsBind[S SlicePattern](m *Matcher, p NodeOrPtn, mode BindMode) S {
+	return matcher.MkPattern[S](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		var hits []*MatchCtx
+		xs := reflect.ValueOf(n)
+		for i := 0; i < xs.Len(); i++ {
+			node := xs.Index(i).Interface().(ast.Node)
+			ctx.Match(p, node, func(c *matcher.Cursor, inner *MatchCtx) {
+				hits = append(hits, inner)
+			})
+		}
+		return mergeBinds(ctx, hits, mode)
+	})
+}
+
 func SliceLenOf[T SlicePattern](m *Matcher, p Predicate[int]) T {
 	return matcher.MkPattern[T](m, func(n ast.Node, ctx *MatchCtx) bool {
 		if n == nil /*ast.Node(nil)*/ {
@@ -51,3 +70,157 @@ func SliceLenLT[T SlicePattern](m *Matcher, n int) T {
 func SliceLenLE[T SlicePattern](m *Matcher, n int) T {
 	return SliceLenOf[T](m, func(ctx *MatchCtx, len int) bool { return len >= n })
 }
+
+// Quantified slice combinators, elements are matched directly, not subtree like SliceContains
+// e.g. all results of the return are nil
+// &ast.ReturnStmt{ Results: SliceForAll[ExprsPattern](m, IdentNameOf(m, "nil")) }
+// e.g. exactly one arg is a context
+// &ast.CallExpr{ Args: SliceExactly[ExprsPattern](m, ctxExprPtn, 1) }
+
+// SliceForAll all elements matched p, binds are shared by elements, true if empty
+func SliceForAll[S SlicePattern](m *Matcher, p NodeOrPtn) S {
+	f := matcher.TryGetOrMkMatchFun[NodePattern](m, p)
+	return matcher.MkPattern[S](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		clone := ctx.Clone()
+		xs := reflect.ValueOf(n)
+		for i := 0; i < xs.Len(); i++ {
+			if !f(elemOf(xs, i), clone) {
+				return false
+			}
+		}
+		ctx.Commit(clone)
+		return true
+	})
+}
+
+// SliceCountOf the count of elements matched p, binds are dropped
+func SliceCountOf[S SlicePattern](m *Matcher, p NodeOrPtn, pred Predicate[int]) S {
+	f := matcher.TryGetOrMkMatchFun[NodePattern](m, p)
+	return matcher.MkPattern[S](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		cnt := 0
+		xs := reflect.ValueOf(n)
+		for i := 0; i < xs.Len(); i++ {
+			if f(elemOf(xs, i), ctx.Clone()) {
+				cnt++
+			}
+		}
+		return pred(ctx, cnt)
+	})
+}
+
+func SliceExactly[S SlicePattern](m *Matcher, p NodeOrPtn, n int) S {
+	return SliceCountOf[S](m, p, func(ctx *MatchCtx, cnt int) bool { return cnt == n })
+}
+
+func SliceAtLeast[S SlicePattern](m *Matcher, p NodeOrPtn, n int) S {
+	return SliceCountOf[S](m, p, func(ctx *MatchCtx, cnt int) bool { return cnt >= n })
+}
+
+func SliceNone[S SlicePattern](m *Matcher, p NodeOrPtn) S {
+	return SliceCountOf[S](m, p, func(ctx *MatchCtx, cnt int) bool { return cnt == 0 })
+}
+
+// SliceAt the element at index i matched p, negative i counts from the end, e.g., -1 is the last
+func SliceAt[S SlicePattern](m *Matcher, i int, p NodeOrPtn) S {
+	f := matcher.TryGetOrMkMatchFun[NodePattern](m, p)
+	return matcher.MkPattern[S](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		xs := reflect.ValueOf(n)
+		idx := i
+		if idx < 0 {
+			idx += xs.Len()
+		}
+		if idx < 0 || idx >= xs.Len() {
+			return false
+		}
+		return f(elemOf(xs, idx), ctx)
+	})
+}
+
+// SliceIndexOf the first element matched p, and binds its index to variable as int constant in ConstBinds,
+// later occurrence of variable must be the same index, e.g., BindConst
+func SliceIndexOf[S SlicePattern](m *Matcher, variable string, p NodeOrPtn) S {
+	f := matcher.TryGetOrMkMatchFun[NodePattern](m, p)
+	return matcher.MkPattern[S](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		xs := reflect.ValueOf(n)
+		for i := 0; i < xs.Len(); i++ {
+			clone := ctx.Clone()
+			if !f(elemOf(xs, i), clone) {
+				continue
+			}
+			idx := constant.MakeInt64(int64(i))
+			if bound, ok := clone.ConstBinds[variable]; ok {
+				if !matcher.ConstEqual(bound, idx) {
+					continue
+				}
+			}
+			clone.ConstBinds[variable] = idx
+			ctx.Commit(clone)
+			return true
+		}
+		return false
+	})
+}
+
+func elemOf(xs reflect.Value, i int) ast.Node {
+	n, _ := xs.Index(i).Interface().(ast.Node)
+	return n
+}
+
+// Unordered elements matched ptns regardless of order, each element is assigned to at most one ptn,
+// allowExtra means elements not assigned are allowed, binds of ptns are kept as assigned
+// e.g. import specs, struct fields, case clause list, interface methods
+func Unordered[S SlicePattern](m *Matcher, allowExtra bool, ptns ...NodeOrPtn) S {
+	fs := make([]MatchFun, len(ptns))
+	for i, p := range ptns {
+		fs[i] = matcher.TryGetOrMkMatchFun[NodePattern](m, p)
+	}
+	return matcher.MkPattern[S](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		xs := reflect.ValueOf(n)
+		if xs.Len() < len(fs) || !allowExtra && xs.Len() != len(fs) {
+			return false
+		}
+		elems := make([]ast.Node, xs.Len())
+		for i := range elems {
+			elems[i] = elemOf(xs, i)
+		}
+		return assign(fs, elems, make([]bool, len(elems)), ctx)
+	})
+}
+
+// assign matches fs[0] with unused elems by backtracking
+func assign(fs []MatchFun, elems []ast.Node, used []bool, ctx *MatchCtx) bool {
+	if len(fs) == 0 {
+		return true
+	}
+	for i, elem := range elems {
+		if used[i] {
+			continue
+		}
+		clone := ctx.Clone()
+		if !fs[0](elem, clone) {
+			continue
+		}
+		used[i] = true
+		if assign(fs[1:], elems, used, clone) {
+			ctx.Commit(clone)
+			return true
+		}
+		used[i] = false
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package combinator
+
+import (
+	"fmt"
+	"go/ast"
+	"strconv"
+	"strings"
+
+	"github.com/goghcrow/go-matcher"
+)
+
+// Tag parsed struct tag, e.g., `json:"name,omitempty" gorm:"column:name;not null"`
+type Tag struct {
+	Raw    string
+	Values []*TagValue // in order, duplicate keys included
+	Err    error       // malformed tag, values before the error are kept
+}
+
+// TagValue value of key in struct tag, comma-separated options are split,
+// e.g., json:"name,omitempty,string", json:"-"
+type TagValue struct {
+	Key     string
+	Value   string
+	Name    string   // the first comma-separated part
+	Options []string // the rest comma-separated parts
+}
+
+// TagSettings gorm-style key:value;key2 settings of tag value, e.g., gorm:"column:id;primaryKey"
+type TagSettings []TagSetting
+
+type TagSetting struct {
+	Key   string
+	Value string // empty if no value, e.g., primaryKey
+}
+
+// ParseTag parses like reflect.StructTag.Lookup, but all values and error are kept
+func ParseTag(raw string) *Tag {
+	t := &Tag{Raw: raw}
+	tag := raw
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			t.Err = fmt.Errorf("malformed struct tag: %s", raw)
+			return t
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			t.Err = fmt.Errorf("malformed struct tag: %s", raw)
+			return t
+		}
+		qval := tag[:i+1]
+		tag = tag[i+1:]
+
+		val, err := strconv.Unquote(qval)
+		if err != nil {
+			t.Err = fmt.Errorf("malformed struct tag: %s: %w", raw, err)
+			return t
+		}
+		parts := strings.Split(val, ",")
+		t.Values = append(t.Values, &TagValue{
+			Key:     key,
+			Value:   val,
+			Name:    parts[0],
+			Options: parts[1:],
+		})
+	}
+	return t
+}
+
+func (t *Tag) String() string { return t.Raw }
+
+// Lookup the first value of key, nil if not found
+func (t *Tag) Lookup(key string) *TagValue {
+	for _, v := range t.Values {
+		if v.Key == key {
+			return v
+		}
+	}
+	return nil
+}
+
+func (t *Tag) DuplicateKeys() []string {
+	return duplicates(len(t.Values), func(i int) string { return t.Values[i].Key })
+}
+
+func (v *TagValue) String() string { return v.Value }
+
+func (v *TagValue) HasOption(opt string) bool {
+	for _, it := range v.Options {
+		if it == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// Settings parses value as gorm-style settings, separated by ;, escaped by \;
+func (v *TagValue) Settings() TagSettings {
+	var (
+		xs   TagSettings
+		buf  string
+		cont bool
+	)
+	for _, part := range strings.Split(v.Value, ";") {
+		if cont {
+			buf += ";" + part
+		} else {
+			buf = part
+		}
+		if cont = strings.HasSuffix(buf, "\\"); cont {
+			buf = buf[:len(buf)-1]
+			continue
+		}
+		if strings.TrimSpace(buf) == "" {
+			continue
+		}
+		k, val, _ := strings.Cut(buf, ":")
+		xs = append(xs, TagSetting{
+			Key:   strings.TrimSpace(k),
+			Value: val,
+		})
+	}
+	return xs
+}
+
+// Lookup the first setting of key, case-insensitive like gorm
+func (s TagSettings) Lookup(key string) (string, bool) {
+	for _, it := range s {
+		if strings.EqualFold(it.Key, key) {
+			return it.Value, true
+		}
+	}
+	return "", false
+}
+
+// DuplicateKeys case-insensitive like gorm
+func (s TagSettings) DuplicateKeys() []string {
+	return duplicates(len(s), func(i int) string { return strings.ToUpper(s[i].Key) })
+}
+
+func duplicates(n int, keyOf func(int) string) (dups []string) {
+	seen := map[string]int{}
+	for i := 0; i < n; i++ {
+		k := keyOf(i)
+		if seen[k]++; seen[k] == 2 {
+			dups = append(dups, k)
+		}
+	}
+	return dups
+}
+
+// TagParsedOf likes TagOf, but tag is parsed, field without tag is parsed as empty Tag
+func TagParsedOf(m *Matcher, p Predicate[*Tag]) BasicLitPattern {
+	return matcher.MkPattern[BasicLitPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		return p(ctx, parseTagLit(n))
+	})
+}
+
+// TagKeyOf tag has key, and the value matched p
+func TagKeyOf(m *Matcher, key string, p Predicate[*TagValue]) BasicLitPattern {
+	return TagParsedOf(m, func(ctx *MatchCtx, t *Tag) bool {
+		v := t.Lookup(key)
+		return v != nil && (p == nil || p(ctx, v))
+	})
+}
+
+// TagKey tag has key, the name matched namePred, nil means any, and has all opts
+// e.g. TagKey(m, "json", nil, "omitempty")
+// e.g. ignored json field: TagKey(m, "json", func(_ *MatchCtx, name string) bool { return name == "-" })
+func TagKey(m *Matcher, key string, namePred Predicate[string], opts ...string) BasicLitPattern {
+	return TagKeyOf(m, key, func(ctx *MatchCtx, v *TagValue) bool {
+		if namePred != nil && !namePred(ctx, v.Name) {
+			return false
+		}
+		for _, opt := range opts {
+			if !v.HasOption(opt) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// TagSettingsOf value of key parsed as gorm-style settings
+// e.g. TagSettingsOf(m, "gorm", func(_ *MatchCtx, s TagSettings) bool { _, ok := s.Lookup("primaryKey"); return ok })
+func TagSettingsOf(m *Matcher, key string, p Predicate[TagSettings]) BasicLitPattern {
+	return TagKeyOf(m, key, func(ctx *MatchCtx, v *TagValue) bool {
+		return p(ctx, v.Settings())
+	})
+}
+
+// TagDuplicateKey tag has duplicate keys, e.g., `json:"a" json:"b"`
+func TagDuplicateKey(m *Matcher) BasicLitPattern {
+	return TagParsedOf(m, func(ctx *MatchCtx, t *Tag) bool {
+		return len(t.DuplicateKeys()) > 0
+	})
+}
+
+// TagMalformed tag can't be parsed, e.g., `json:name`
+func TagMalformed(m *Matcher) BasicLitPattern {
+	return TagParsedOf(m, func(ctx *MatchCtx, t *Tag) bool {
+		return t.Err != nil
+	})
+}
+
+// BindTag match tag and bind the parsed *Tag to variable in Vals, the tag node is bound as Bind,
+// so can be used in message template, e.g., {{(.tag.Lookup "json").Name}}
+func BindTag(m *Matcher, variable string, ptn BasicLitPattern) BasicLitPattern {
+	return And(m, ptn, matcher.MkPattern[BasicLitPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
+		if n == nil /*ast.Node(nil)*/ {
+			return false
+		}
+		ctx.Binds[variable] = n
+		ctx.Vals[variable] = parseTagLit(n)
+		return true
+	}))
+}
+
+func parseTagLit(n ast.Node) *Tag {
+	tagLit, _ := n.(*ast.BasicLit)
+	if tagLit == nil {
+		return &Tag{}
+	}
+	tag, err := strconv.Unquote(tagLit.Value)
+	if err != nil {
+		return &Tag{Raw: tagLit.Value, Err: err}
+	}
+	return ParseTag(tag)
+}
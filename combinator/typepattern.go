@@ -0,0 +1,189 @@
+package combinator
+
+import "go/types"
+
+// TypePattern structural pattern of types.Type, mirroring go/types,
+// can be used as predicate of TypeOf, IdentTypeOf, SelectorTypeOf, etc.
+// nil TypePattern is wildcard
+// e.g. map[string][]*gorm.DB
+// Map(Basic(types.String), Slice(Pointer(Named("gorm.io/gorm", "DB"))))
+type (
+	TypePattern      = Predicate[types.Type]
+	FieldTypePattern = Predicate[*types.Var]
+)
+
+// AnyChanDir for Chan, matches all directions
+const AnyChanDir types.ChanDir = -1
+
+func matchType(p TypePattern, ctx *MatchCtx, t types.Type) bool {
+	if p == nil {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	return p(ctx, t)
+}
+
+func AnyType() TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool { return t != nil }
+}
+
+// TypeIs identical to ty
+func TypeIs(ty types.Type) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		return t != nil && types.Identical(t, ty)
+	}
+}
+
+// TypeBind binds matched type to variable, or must be identical to the bound one
+// e.g. map[K]K: Map(TypeBind("K", nil), TypeBind("K", nil))
+func TypeBind(variable string, p TypePattern) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		if !matchType(p, ctx, t) {
+			return false
+		}
+		if bound, ok := ctx.TypeBinds[variable]; ok {
+			return types.Identical(bound, t)
+		}
+		ctx.TypeBinds[variable] = t
+		return true
+	}
+}
+
+func Underlying(p TypePattern) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		return t != nil && matchType(p, ctx, t.Underlying())
+	}
+}
+
+func Basic(kind types.BasicKind) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		b, ok := unalias(t).(*types.Basic)
+		return ok && b.Kind() == kind
+	}
+}
+
+func Pointer(elem TypePattern) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		ptr, ok := unalias(t).(*types.Pointer)
+		return ok && matchType(elem, ctx, ptr.Elem())
+	}
+}
+
+func Slice(elem TypePattern) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		s, ok := unalias(t).(*types.Slice)
+		return ok && matchType(elem, ctx, s.Elem())
+	}
+}
+
+// Array len < 0 means any length
+func Array(len int64, elem TypePattern) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		a, ok := unalias(t).(*types.Array)
+		return ok &&
+			(len < 0 || a.Len() == len) &&
+			matchType(elem, ctx, a.Elem())
+	}
+}
+
+func Map(key, val TypePattern) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		mt, ok := unalias(t).(*types.Map)
+		return ok &&
+			matchType(key, ctx, mt.Key()) &&
+			matchType(val, ctx, mt.Elem())
+	}
+}
+
+// Chan dir can be AnyChanDir
+func Chan(dir types.ChanDir, elem TypePattern) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		ch, ok := unalias(t).(*types.Chan)
+		return ok &&
+			(dir == AnyChanDir || ch.Dir() == dir) &&
+			matchType(elem, ctx, ch.Elem())
+	}
+}
+
+// Named pkg and name are matched by path.Match, pkg of predeclared type is empty, e.g., error
+// typeArgs nil means any type arguments, otherwise matched one by one
+func Named(pkg, name string, typeArgs ...TypePattern) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		named, ok := unalias(t).(*types.Named)
+		if !ok {
+			return false
+		}
+		obj := named.Obj()
+		pkgPath := ""
+		if obj.Pkg() != nil {
+			pkgPath = obj.Pkg().Path()
+		}
+		if !globMatch(pkg, pkgPath) || !globMatch(name, obj.Name()) {
+			return false
+		}
+		if typeArgs == nil {
+			return true
+		}
+		args := named.TypeArgs()
+		if args.Len() != len(typeArgs) {
+			return false
+		}
+		for i, p := range typeArgs {
+			if !matchType(p, ctx, args.At(i)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Struct fields are matched one by one in declaration order
+func Struct(fields ...FieldTypePattern) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		st, ok := unalias(t).(*types.Struct)
+		if !ok || st.NumFields() != len(fields) {
+			return false
+		}
+		for i, p := range fields {
+			if p != nil && !p(ctx, st.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Field name is matched by path.Match
+func Field(name string, ty TypePattern) FieldTypePattern {
+	return func(ctx *MatchCtx, f *types.Var) bool {
+		return globMatch(name, f.Name()) && matchType(ty, ctx, f.Type())
+	}
+}
+
+// Signature params or results nil means any, receiver is ignored
+func Signature(params, results []TypePattern, variadic bool) TypePattern {
+	return func(ctx *MatchCtx, t types.Type) bool {
+		sig, ok := unalias(t).(*types.Signature)
+		return ok &&
+			sig.Variadic() == variadic &&
+			matchTuple(params, ctx, sig.Params()) &&
+			matchTuple(results, ctx, sig.Results())
+	}
+}
+
+func matchTuple(ps []TypePattern, ctx *MatchCtx, tup *types.Tuple) bool {
+	if ps == nil {
+		return true
+	}
+	if tup.Len() != len(ps) {
+		return false
+	}
+	for i, p := range ps {
+		if !matchType(p, ctx, tup.At(i).Type()) {
+			return false
+		}
+	}
+	return true
+}
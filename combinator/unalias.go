@@ -0,0 +1,8 @@
+//go:build !go1.22
+
+package combinator
+
+import "go/types"
+
+// unalias types.Alias is introduced in go1.22
+func unalias(t types.Type) types.Type { return t }
@@ -0,0 +1,8 @@
+//go:build go1.22
+
+package combinator
+
+import "go/types"
+
+// unalias alias is materialized as types.Alias if gotypesalias=1, default since go1.23
+func unalias(t types.Type) types.Type { return types.Unalias(t) }
@@ -0,0 +1,62 @@
+package matcher
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// matchCommutative tries the original order firstly, then swapped operands
+func (m *Matcher) matchCommutative(x, y *ast.BinaryExpr, ctx *MatchCtx) bool {
+	clone := ctx.Clone()
+	if m.matchToken(x.Op, y.Op, clone) &&
+		m.matchExpr(x.X, y.X, clone) &&
+		m.matchExpr(x.Y, y.Y, clone) {
+		ctx.Commit(clone)
+		return true
+	}
+
+	op, ok := swappedOp(ctx, y)
+	if !ok {
+		return false
+	}
+	clone = ctx.Clone()
+	if m.matchToken(x.Op, op, clone) &&
+		m.matchExpr(x.X, y.Y, clone) &&
+		m.matchExpr(x.Y, y.X, clone) {
+		ctx.Commit(clone)
+		return true
+	}
+	return false
+}
+
+// swappedOp returns the operator after swapping operands of y, e.g., a < b => b > a
+func swappedOp(ctx *MatchCtx, y *ast.BinaryExpr) (token.Token, bool) {
+	switch y.Op {
+	case token.MUL, token.EQL, token.NEQ,
+		token.LAND, token.LOR,
+		token.AND, token.OR, token.XOR:
+		return y.Op, true
+	case token.ADD:
+		// string concatenation is ordered, unknown type is treated as string,
+		// so is any type without type info
+		if !hasTypeInfo(ctx) {
+			return y.Op, false
+		}
+		ty := ctx.TypeOf(y)
+		if ty == nil {
+			return y.Op, false
+		}
+		basic, _ := ty.Underlying().(*types.Basic)
+		return y.Op, basic != nil && basic.Info()&types.IsNumeric != 0
+	case token.LSS:
+		return token.GTR, true
+	case token.GTR:
+		return token.LSS, true
+	case token.LEQ:
+		return token.GEQ, true
+	case token.GEQ:
+		return token.LEQ, true
+	}
+	return y.Op, false
+}
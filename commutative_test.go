@@ -0,0 +1,42 @@
+package matcher_test
+
+import (
+	"go/ast"
+	"go/token"
+	"testing"
+
+	"github.com/goghcrow/go-matcher"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestCommutative(t *testing.T) {
+	const src = `package p
+
+var a, b = 1, 2
+var _ = b + a
+var _ = b * a
+`
+	bin := func(op token.Token) *ast.BinaryExpr {
+		return &ast.BinaryExpr{X: &ast.Ident{Name: "a"}, Op: op, Y: &ast.Ident{Name: "b"}}
+	}
+	for _, tt := range []struct {
+		name string
+		pkg  *packages.Package
+		op   token.Token
+		want int
+	}{
+		{name: "typed add", pkg: loadSrc(t, src), op: token.ADD, want: 1},
+		{name: "typed mul", pkg: loadSrc(t, src), op: token.MUL, want: 1},
+		// + is treated as order-sensitive without type info
+		{name: "untyped add", pkg: parseSrc(t, src), op: token.ADD, want: 0},
+		{name: "untyped mul", pkg: parseSrc(t, src), op: token.MUL, want: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := matcher.New()
+			m.Commutative = true
+			if got := matchedCount(m, tt.pkg, bin(tt.op)); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
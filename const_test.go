@@ -0,0 +1,57 @@
+package matcher_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/goghcrow/go-matcher"
+	"golang.org/x/tools/go/packages"
+)
+
+// parseSrc likes loadSrc, but without type checking
+func parseSrc(t *testing.T, src string) *packages.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &packages.Package{
+		Name:   f.Name.Name,
+		Fset:   fset,
+		Syntax: []*ast.File{f},
+	}
+}
+
+func matchedCount(m *matcher.Matcher, pkg *packages.Package, ptn ast.Node) (n int) {
+	m.Match(pkg, ptn, pkg.Syntax[0], func(c *matcher.Cursor, ctx *matcher.MatchCtx) { n++ })
+	return n
+}
+
+func TestMatchConstValue(t *testing.T) {
+	const src = `package p
+
+const a = 1 + 1
+const b = 2
+`
+	two := &ast.BasicLit{Kind: token.INT, Value: "2"}
+	for _, tt := range []struct {
+		name string
+		pkg  *packages.Package
+		want int
+	}{
+		{name: "typed", pkg: loadSrc(t, src), want: 2},
+		// fallback to literal comparison
+		{name: "untyped", pkg: parseSrc(t, src), want: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := matcher.New()
+			m.MatchConstValue = true
+			if got := matchedCount(m, tt.pkg, two); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package matcher
 
 import (
 	"go/ast"
+	"go/constant"
 	"go/types"
 
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/go/types/typeutil"
 )
@@ -15,32 +17,106 @@ type (
 	Binds      map[PatternVar]ast.Node
 
 	MatchCtx struct {
-		Pkg     *Package
-		Stack   []ast.Node
-		Names   []string // stack parent fileld name
-		Binds   Binds
-		Matcher *Matcher
+		Pkg        *Package
+		Stack      []ast.Node
+		Names      []string // stack parent fileld name
+		Binds      Binds
+		TypeBinds  map[PatternVar]types.Type
+		ObjBinds   map[PatternVar]types.Object
+		ConstBinds map[PatternVar]constant.Value
+		Vals       map[PatternVar]any // other bound values, e.g., parsed struct tag
+		Matcher    *Matcher
+
+		// bijection of local objects for Matcher.AlphaEquiv,
+		// key of pattern is types.Object, or name if pattern ident is not type checked
+		alphaX map[any]types.Object
+		alphaY map[types.Object]any
+
+		memo map[any]any // shared by all MatchCtx of one Match call, see Memo
 	}
 	MatchFun func(n ast.Node, ctx *MatchCtx) bool
 )
 
-func newMCtx(m *Matcher, pkg *Package, stack []ast.Node, names []string) *MatchCtx {
+func newMCtx(m *Matcher, pkg *Package, stack []ast.Node, names []string, memo map[any]any) *MatchCtx {
 	return &MatchCtx{
-		Matcher: m,
-		Pkg:     pkg,
-		Stack:   stack,
-		Names:   names,
-		Binds:   map[PatternVar]ast.Node{},
+		Matcher:    m,
+		Pkg:        pkg,
+		Stack:      stack,
+		Names:      names,
+		Binds:      map[PatternVar]ast.Node{},
+		TypeBinds:  map[PatternVar]types.Type{},
+		ObjBinds:   map[PatternVar]types.Object{},
+		ConstBinds: map[PatternVar]constant.Value{},
+		Vals:       map[PatternVar]any{},
+		alphaX:     map[any]types.Object{},
+		alphaY:     map[types.Object]any{},
+		memo:       memo,
 	}
 }
 
+// Clone copies ctx with binds, used for matching speculatively,
+// binds of the clone can be committed back by Commit if matched
+func (c *MatchCtx) Clone() *MatchCtx {
+	clone := *c
+	clone.Binds = copyMap(c.Binds)
+	clone.TypeBinds = copyMap(c.TypeBinds)
+	clone.ObjBinds = copyMap(c.ObjBinds)
+	clone.ConstBinds = copyMap(c.ConstBinds)
+	clone.Vals = copyMap(c.Vals)
+	clone.alphaX, clone.alphaY = copyAlpha(c.alphaX, c.alphaY)
+	return &clone
+}
+
+// Commit binds of the clone
+func (c *MatchCtx) Commit(clone *MatchCtx) {
+	c.Binds = clone.Binds
+	c.TypeBinds = clone.TypeBinds
+	c.ObjBinds = clone.ObjBinds
+	c.ConstBinds = clone.ConstBinds
+	c.Vals = clone.Vals
+	c.alphaX = clone.alphaX
+	c.alphaY = clone.alphaY
+}
+
+// TryAlpha calls f, the renaming of Matcher.AlphaEquiv made by f is rolled back if f returns false,
+// other binds are left as-is, e.g., the failed branch of Or shouldn't rename the later one
+func (c *MatchCtx) TryAlpha(f func() bool) bool {
+	if !c.Matcher.AlphaEquiv {
+		return f()
+	}
+	x, y := copyAlpha(c.alphaX, c.alphaY)
+	if f() {
+		return true
+	}
+	c.alphaX, c.alphaY = x, y
+	return false
+}
+
+// Memo caches the value made by mk with key during the current Match call,
+// e.g., cfg of function body, computed once for all nodes of the function,
+// key should be of unexported type to avoid collision
+func (c *MatchCtx) Memo(key any, mk func() any) any {
+	if c.memo == nil {
+		return mk()
+	}
+	if v, ok := c.memo[key]; ok {
+		return v
+	}
+	v := mk()
+	c.memo[key] = v
+	return v
+}
+
 func (c *MatchCtx) match(x, y ast.Node) bool            { return c.Matcher.match(x, y, c) }
 func (c *MatchCtx) Match(ptn, node ast.Node, f Matched) { c.Matcher.Match(c.Pkg, ptn, node, f) }
 func (c *MatchCtx) Matched(ptn, root ast.Node) bool     { return c.Matcher.Matched(c.Pkg, ptn, root) }
+func (c *MatchCtx) MatchPruned(ptn, node ast.Node, prune func(*Cursor) bool, f Matched) {
+	c.Matcher.MatchPruned(c.Pkg, ptn, node, prune, f)
+}
 
 func (c *MatchCtx) TypeInfo() *types.Info                { return c.Pkg.TypesInfo }
 func (c *MatchCtx) ObjectOf(id *ast.Ident) types.Object  { return c.TypeInfo().ObjectOf(id) }
-func (c *MatchCtx) TypeOf(e ast.Expr) types.Type         { return c.TypeInfo().TypeOf(e) }
+func (c *MatchCtx) TypeOf(e ast.Expr) types.Type         { return c.TypeInfo().TypeOf(c.equivOf(e)) }
 func (c *MatchCtx) Callee(cl *ast.CallExpr) types.Object { return typeutil.Callee(c.TypeInfo(), cl) }
 
 func (c *MatchCtx) ShowPos(n ast.Node) string {
@@ -65,3 +141,73 @@ func (c *MatchCtx) EnclosingFunc() FuncNode {
 	}
 	return nil
 }
+
+// OriginOf the original node of n if n is made up by normalization, otherwise n itself, see Matcher.Normalize
+// e.g., var x = 1 of x := 1, x++ of x += 1
+func (c *MatchCtx) OriginOf(n ast.Node) ast.Node {
+	if IsNilNode(n) || IsPseudoNode(n) {
+		return n
+	}
+	if o, ok := c.madeUpNodes()[n]; ok {
+		return o.origin
+	}
+	return n
+}
+
+// equivOf the original expression of e if e is made up with the same value, e.g., new(T) of &T{}
+func (c *MatchCtx) equivOf(e ast.Expr) ast.Expr {
+	if IsNilNode(e) {
+		return e
+	}
+	if o, ok := c.madeUpNodes()[e]; ok && o.equiv {
+		return o.origin.(ast.Expr)
+	}
+	return e
+}
+
+// StackOf returns the parent chain and field names of n like Stack and Names,
+// n must be Stack[0] or its descendant, e.g., node matched by sub-pattern,
+// otherwise nil returned, e.g., pseudo node
+// made-up node of normalization is located by its original node, see OriginOf
+func (c *MatchCtx) StackOf(n ast.Node) ([]ast.Node, []string) {
+	n = c.OriginOf(n)
+	if len(c.Stack) == 0 || IsNilNode(n) || IsPseudoNode(n) {
+		return nil, nil
+	}
+	if c.Stack[0] == n {
+		return c.Stack, c.Names
+	}
+
+	var (
+		path  []ast.Node
+		names []string
+		found bool
+	)
+	astutil.Apply(c.Stack[0], func(cur *astutil.Cursor) bool {
+		if found {
+			return false
+		}
+		path = append(path, cur.Node())
+		names = append(names, cur.Name())
+		found = cur.Node() == n
+		return !found
+	}, func(cur *astutil.Cursor) bool {
+		if !found {
+			path = path[:len(path)-1]
+			names = names[:len(names)-1]
+		}
+		return true
+	})
+	if !found {
+		return nil, nil
+	}
+
+	// path[0] is Stack[0]
+	stack := make([]ast.Node, 0, len(path)-1+len(c.Stack))
+	fields := make([]string, 0, len(path)-1+len(c.Names))
+	for i := len(path) - 1; i > 0; i-- {
+		stack = append(stack, path[i])
+		fields = append(fields, names[i])
+	}
+	return append(stack, c.Stack...), append(fields, c.Names...)
+}
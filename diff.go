@@ -0,0 +1,134 @@
+package matcher
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseUnifiedDiff collects changed line ranges from the new side of a unified diff,
+// e.g., output of `git diff -U0`
+// Notice: added or modified lines are recorded, for pure deletion,
+// the new-side line before the deleted lines is recorded, or line 1 if deleted at the beginning,
+// e.g., @@ -5,2 +4,0 @@ records line 4
+func ParseUnifiedDiff(r io.Reader) (LineRanges, error) {
+	ranges := LineRanges{}
+
+	var (
+		file             string // current new-side file, "" means /dev/null
+		newLine          int
+		oldLeft, newLeft int  // remaining lines of current hunk
+		deleted          bool // pending deletion not replaced by added lines
+	)
+	flushDeleted := func() {
+		if deleted && file != "" {
+			line := newLine - 1
+			if line < 1 {
+				line = 1
+			}
+			ranges.Add(file, line, line)
+		}
+		deleted = false
+	}
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNo := 1; s.Scan(); lineNo++ {
+		line := s.Text()
+
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				if file != "" {
+					ranges.Add(file, newLine, newLine)
+				}
+				deleted = false
+				newLine++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				deleted = true
+				oldLeft--
+			case strings.HasPrefix(line, "\\"):
+				// \ No newline at end of file
+			default:
+				// context line, maybe empty line without leading space
+				flushDeleted()
+				newLine++
+				oldLeft--
+				newLeft--
+			}
+			if oldLeft <= 0 && newLeft <= 0 {
+				flushDeleted()
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			file = diffFileName(line[len("+++ "):])
+		case strings.HasPrefix(line, "@@ "):
+			oldCnt, newStart, newCnt, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			newLine, oldLeft, newLeft = newStart, oldCnt, newCnt
+			if newCnt == 0 {
+				// pure deletion, newStart is the line before deleted lines
+				newLine++
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
+
+func diffFileName(s string) string {
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i] // strip timestamp
+	}
+	s = strings.TrimSpace(s)
+	if s == "/dev/null" {
+		return ""
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	if strings.HasPrefix(s, "b/") {
+		s = s[len("b/"):]
+	}
+	return s
+}
+
+// parseHunkHeader parses @@ -l[,s] +l[,s] @@
+func parseHunkHeader(line string) (oldCnt, newStart, newCnt int, err error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[3] != "@@" ||
+		!strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, 0, fmt.Errorf("invalid hunk header: %s", line)
+	}
+	_, oldCnt, err = parseHunkRange(fields[1][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid hunk header: %s", line)
+	}
+	newStart, newCnt, err = parseHunkRange(fields[2][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid hunk header: %s", line)
+	}
+	return oldCnt, newStart, newCnt, nil
+}
+
+func parseHunkRange(s string) (start, cnt int, err error) {
+	cnt = 1
+	if i := strings.IndexByte(s, ','); i >= 0 {
+		if cnt, err = strconv.Atoi(s[i+1:]); err != nil {
+			return 0, 0, err
+		}
+		s = s[:i]
+	}
+	start, err = strconv.Atoi(s)
+	return start, cnt, err
+}
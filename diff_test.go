@@ -0,0 +1,88 @@
+package matcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseUnifiedDiff(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		diff string
+		want LineRanges
+	}{
+		{
+			name: "added",
+			diff: `--- a/x.go
++++ b/x.go
+@@ -3,0 +4,2 @@
++a
++b
+`,
+			want: LineRanges{"x.go": {{4, 5}}},
+		},
+		{
+			name: "modified",
+			diff: `--- a/x.go
++++ b/x.go
+@@ -10 +8 @@
+-c
++d
+`,
+			want: LineRanges{"x.go": {{8, 8}}},
+		},
+		{
+			// the line before deleted lines
+			name: "pure deletion",
+			diff: `--- a/x.go
++++ b/x.go
+@@ -5,2 +4,0 @@
+-a
+-b
+`,
+			want: LineRanges{"x.go": {{4, 4}}},
+		},
+		{
+			name: "pure deletion at beginning",
+			diff: `--- a/x.go
++++ b/x.go
+@@ -1,2 +0,0 @@
+-a
+-b
+`,
+			want: LineRanges{"x.go": {{1, 1}}},
+		},
+		{
+			name: "deletion with context",
+			diff: `--- a/x.go
++++ b/x.go
+@@ -20,3 +18,2 @@
+ ctx1
+-gone
+ ctx2
+`,
+			want: LineRanges{"x.go": {{18, 18}}},
+		},
+		{
+			name: "deleted file",
+			diff: `--- a/x.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-a
+-b
+`,
+			want: LineRanges{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUnifiedDiff(strings.NewReader(tt.diff))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package matcher
 import (
 	"flag"
 	"go/ast"
+	"go/constant"
+	"go/token"
 	"os"
 	"reflect"
 	"strings"
@@ -28,6 +30,23 @@ func postOrder(root ast.Node, f astutil.ApplyFunc) {
 	astutil.Apply(root, nil, f)
 }
 
+func mkPreFilter(filter func(ast.Node) bool) astutil.ApplyFunc {
+	if filter == nil {
+		return nil
+	}
+	return func(c *astutil.Cursor) bool {
+		return filter(c.Node())
+	}
+}
+
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	cp := make(map[K]V, len(m))
+	for k, v := range m {
+		cp[k] = v
+	}
+	return cp
+}
+
 func IsNilNode(n ast.Node) bool {
 	if n == nil {
 		return true
@@ -37,3 +56,20 @@ func IsNilNode(n ast.Node) bool {
 	}
 	return false
 }
+
+// ConstEqual compares constants, false if kinds are incompatible, e.g., string and int
+func ConstEqual(x, y constant.Value) bool {
+	if x == nil || y == nil {
+		return false
+	}
+	if x.Kind() == constant.Unknown || y.Kind() == constant.Unknown {
+		return false
+	}
+	isNumeric := func(k constant.Kind) bool {
+		return k == constant.Int || k == constant.Float || k == constant.Complex
+	}
+	if x.Kind() != y.Kind() && !(isNumeric(x.Kind()) && isNumeric(y.Kind())) {
+		return false
+	}
+	return constant.Compare(x, token.EQL, y)
+}
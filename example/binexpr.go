@@ -50,3 +50,26 @@ func PatternOfIdCompare(m *Matcher) ast.Node {
 		},
 	)
 }
+
+// PatternOfIdCompareCommutative likes PatternOfIdCompare, but both orders are matched by one pattern,
+// m.Commutative must be true, e.g., x < id is matched as id > x
+func PatternOfIdCompareCommutative(m *Matcher) ast.Node {
+	isIdIdent := IdentOf(m, func(_ *MatchCtx, id *ast.Ident) bool {
+		return strings.HasSuffix(strings.ToLower(id.Name), "id")
+	})
+	isIdPtn := OrEx[ExprPattern](m,
+		&ast.SelectorExpr{Sel: isIdIdent},
+		isIdIdent,
+	)
+	isCmpTokPtn := matcher.MkPattern[TokenPattern](m, func(n ast.Node, ctx *MatchCtx) bool {
+		tok := token.Token(n.(TokenNode))
+		return tok == token.LSS || tok == token.GTR || tok == token.LEQ || tok == token.GEQ
+	})
+	notIntLit := Not(m, LitKindOf(m, token.INT))
+
+	return &ast.BinaryExpr{
+		X:  isIdPtn,
+		Op: isCmpTokPtn,
+		Y:  notIntLit,
+	}
+}
@@ -21,3 +21,19 @@ func PatternOfStructFieldWithJsonTag(m *Matcher) ast.Node {
 		),
 	}
 }
+
+// PatternOfIgnoredJsonFieldWithGormColumn e.g. json:"-" gorm:"column:name"
+func PatternOfIgnoredJsonFieldWithGormColumn(m *Matcher) ast.Node {
+	return &ast.Field{
+		Tag: BindTag(m,
+			"tag",
+			And(m,
+				TagKey(m, "json", func(_ *MatchCtx, name string) bool { return name == "-" }),
+				TagSettingsOf(m, "gorm", func(_ *MatchCtx, s TagSettings) bool {
+					_, ok := s.Lookup("column")
+					return ok
+				}),
+			),
+		),
+	}
+}
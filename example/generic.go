@@ -0,0 +1,22 @@
+package example
+
+import (
+	"go/ast"
+	"go/types"
+
+	. "github.com/goghcrow/go-matcher/combinator"
+)
+
+func PatternOfSlicesContainsStruct(m *Matcher) ast.Node {
+	// slices.Contains(xs, x) or slices.Contains[[]S](xs, x), S is struct
+	return InstanceCalleeOf(m, func(_ *MatchCtx, inst *Instance) bool {
+		obj := inst.Obj
+		if obj.Pkg() == nil || obj.Pkg().Path() != "slices" || obj.Name() != "Contains" {
+			return false
+		}
+		// func Contains[S ~[]E, E comparable](s S, v E) bool
+		elem := inst.TypeArgs[1]
+		_, isStruct := elem.Underlying().(*types.Struct)
+		return isStruct
+	})
+}
@@ -78,7 +78,7 @@ func PatternOfGormTablerTableName(m *Matcher, gormTabler *types.Interface) ast.N
 func PatternOfNonCompositeModelCall1(m *Matcher, gormDB types.Object) ast.Node {
 	// db.Model(&Model{})
 	// db.Model(Model{})
-	// gormDB := .Loader.MustLookup("gorm.io/gorm.DB")
+	// gormDB := l.MustLookup("gorm.io/gorm.DB")
 	return And(m,
 		MethodCallee(m, gormDB, "Model", true),
 		matcher.PatternOf[CallExprPattern](m, &ast.CallExpr{
@@ -95,7 +95,7 @@ func PatternOfNonCompositeModelCall1(m *Matcher, gormDB types.Object) ast.Node {
 }
 
 func PatternOfNonCompositeModelCall2(m *Matcher, gormDB types.Object) func() ast.Node {
-	// gormDB := .Loader.MustLookupType("gorm.io/gorm.DB")
+	// gormDB := l.MustLookup("gorm.io/gorm.DB")
 	gormDBPtr := types.NewPointer(gormDB.Type())
 	return func() ast.Node {
 		// db.Model(&Model{})
@@ -0,0 +1,31 @@
+package example
+
+import (
+	"go/ast"
+	"go/types"
+
+	. "github.com/goghcrow/go-matcher/combinator"
+)
+
+func PatternOfShadowedErrOrCtx(m *Matcher) ast.Node {
+	// if err := f(); err != nil { ... } shadows outer err
+	return IdentShadowsOf(m, func(_ *MatchCtx, s *Shadowing) bool {
+		name := s.Obj.Name()
+		if name != "err" && name != "ctx" {
+			return false
+		}
+		_, isVar := s.Shadowed.(*types.Var)
+		return isVar
+	})
+}
+
+func PatternOfUseOfLocalVar(m *Matcher) ast.Node {
+	// uses of a variable declared in the enclosing function
+	return And(m,
+		IdentUseOf(m, func(_ *MatchCtx, obj types.Object) bool {
+			_, isVar := obj.(*types.Var)
+			return isVar
+		}),
+		IdentDeclaredInFunc(m),
+	)
+}
@@ -0,0 +1,254 @@
+package matcher
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// LoadMode is needed by Matcher, syntax and types info of all packages
+const LoadMode = packages.NeedName |
+	packages.NeedFiles |
+	packages.NeedImports |
+	packages.NeedDeps |
+	packages.NeedTypes |
+	packages.NeedSyntax |
+	packages.NeedTypesInfo |
+	packages.NeedTypesSizes
+
+type Loader struct {
+	Cfg  *packages.Config
+	Fset *token.FileSet
+	Pkgs []*Package          // packages matched patterns
+	All  map[string]*Package // all loaded packages by path, including dependencies and lazily loaded ones
+
+	missing map[string]bool
+}
+
+// NewLoader loads packages of patterns in dir, and all dependencies
+func NewLoader(dir string, patterns ...string) (*Loader, error) {
+	fset := token.NewFileSet()
+	cfg := &packages.Config{
+		Mode: LoadMode,
+		Dir:  dir,
+		Fset: fset,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	var errs []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, e := range p.Errors {
+			errs = append(errs, e.Error())
+		}
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("load %v:\n%s", patterns, strings.Join(errs, "\n"))
+	}
+
+	l := &Loader{
+		Cfg:     cfg,
+		Fset:    fset,
+		Pkgs:    pkgs,
+		All:     map[string]*Package{},
+		missing: map[string]bool{},
+	}
+	l.addAll(pkgs)
+	return l, nil
+}
+
+func MustLoad(dir string, patterns ...string) *Loader {
+	l, err := NewLoader(dir, patterns...)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+func (l *Loader) addAll(pkgs []*Package) {
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		if _, ok := l.All[p.PkgPath]; !ok {
+			l.All[p.PkgPath] = p
+		}
+	})
+}
+
+// Package returns the loaded package of path, resolved through All firstly, i.e., the import graph of Pkgs,
+// package not depended by loaded packages is loaded lazily
+// Notice: lazily loaded package is type checked in a separate package graph,
+// so its objects and types are not identical to those seen through Pkgs,
+// e.g., if gorm.io/gorm is loaded lazily, the type of gorm.DB.Statement.Context is not identical to
+// context.Context imported by Pkgs, though both are named context.Context,
+// load all needed packages by NewLoader patterns to compare types across packages
+func (l *Loader) Package(path string) *Package {
+	if pkg, ok := l.All[path]; ok {
+		return pkg
+	}
+	if l.missing[path] {
+		return nil
+	}
+	pkgs, err := packages.Load(l.Cfg, path)
+	if err != nil || len(pkgs) != 1 || len(pkgs[0].Errors) > 0 || pkgs[0].Types == nil {
+		l.missing[path] = true
+		return nil
+	}
+	l.addAll(pkgs)
+	return pkgs[0]
+}
+
+// Lookup object by qualified name, returns nil if not found
+// e.g., context.Context, gorm.io/gorm.DB, gorm.io/gorm.DB.Model, net/http.DefaultClient
+// member of type can be field or method, method of pointer receiver included
+// Notice: object of lazily loaded package is not identical to the one seen through Pkgs, see Package
+func (l *Loader) Lookup(qualified string) types.Object {
+	cands := splitQualified(qualified)
+	// try loaded packages firstly, avoid loading candidates path lazily
+	for _, it := range cands {
+		if pkg, ok := l.All[it.path]; ok {
+			if obj := lookupInPkg(pkg.Types, it.names); obj != nil {
+				return obj
+			}
+		}
+	}
+	for _, it := range cands {
+		if _, ok := l.All[it.path]; ok {
+			continue
+		}
+		if pkg := l.Package(it.path); pkg != nil {
+			if obj := lookupInPkg(pkg.Types, it.names); obj != nil {
+				return obj
+			}
+		}
+	}
+	return nil
+}
+
+func (l *Loader) MustLookup(qualified string) types.Object {
+	obj := l.Lookup(qualified)
+	assert(obj != nil, qualified+" not found")
+	return obj
+}
+
+// LookupType lookup type by qualified name, returns nil if not found or not type
+func (l *Loader) LookupType(qualified string) types.Type {
+	tn, _ := l.Lookup(qualified).(*types.TypeName)
+	if tn == nil {
+		return nil
+	}
+	return tn.Type()
+}
+
+func (l *Loader) MustLookupType(qualified string) types.Type {
+	ty := l.LookupType(qualified)
+	assert(ty != nil, qualified+" not found or not type")
+	return ty
+}
+
+type qualifiedName struct {
+	path  string
+	names []string // Name or Type.Member
+}
+
+// splitQualified returns all possible splitting,
+// because path may contain dot, e.g., gopkg.in/yaml.v3.Node
+func splitQualified(qualified string) (cands []qualifiedName) {
+	start := strings.LastIndexByte(qualified, '/') + 1
+	for i := start; i < len(qualified); i++ {
+		if qualified[i] != '.' {
+			continue
+		}
+		names := strings.Split(qualified[i+1:], ".")
+		if len(names) > 2 {
+			continue
+		}
+		cands = append(cands, qualifiedName{qualified[:i], names})
+	}
+	return cands
+}
+
+func lookupInPkg(pkg *types.Package, names []string) types.Object {
+	if pkg == nil {
+		return nil
+	}
+	obj := pkg.Scope().Lookup(names[0])
+	if obj == nil || len(names) == 1 {
+		return obj
+	}
+	if _, ok := obj.(*types.TypeName); !ok {
+		return nil
+	}
+	member, _, _ := types.LookupFieldOrMethod(obj.Type(), true, pkg, names[1])
+	return member
+}
+
+// EvalType evaluates type expression in the scope of the loaded package,
+// e.g., map[string]*sync.Mutex, func(context.Context) error
+// qualified identifier must be imported by one of the files of pkg
+func (l *Loader) EvalType(pkgPath, expr string) (types.Type, error) {
+	pkg := l.Package(pkgPath)
+	if pkg == nil {
+		return nil, fmt.Errorf("eval type %q: package %s not found", expr, pkgPath)
+	}
+	return EvalType(pkg, expr)
+}
+
+func (l *Loader) MustEvalType(pkgPath, expr string) types.Type {
+	ty, err := l.EvalType(pkgPath, expr)
+	if err != nil {
+		panic(err)
+	}
+	return ty
+}
+
+// EvalType evaluates type expression in the scope of pkg by types.Eval,
+// tried in file scopes one by one, because imports are file scoped
+func EvalType(pkg *Package, expr string) (types.Type, error) {
+	if pkg.Types == nil {
+		return nil, fmt.Errorf("eval type %q: no types of package %s", expr, pkg.PkgPath)
+	}
+
+	poss := []token.Pos{token.NoPos} // package scope
+	if len(pkg.Syntax) > 0 {
+		poss = poss[:0]
+		for _, f := range pkg.Syntax {
+			poss = append(poss, f.Name.Pos())
+		}
+	}
+
+	var evalErr error
+	for _, pos := range poss {
+		tv, err := types.Eval(pkg.Fset, pkg.Types, pos, expr)
+		if err != nil {
+			// prefer the error of file which imports the package of qualified identifier
+			if evalErr == nil || isUndefinedIdent(evalErr) {
+				evalErr = err
+			}
+			continue
+		}
+		if !tv.IsType() {
+			return nil, fmt.Errorf("eval type %q in %s: not a type", expr, pkg.PkgPath)
+		}
+		return tv.Type, nil
+	}
+	if isUndefinedIdent(evalErr) {
+		return nil, fmt.Errorf("eval type %q in %s: %w "+
+			"(package of qualified identifier must be imported by one of the files)", expr, pkg.PkgPath, evalErr)
+	}
+	return nil, fmt.Errorf("eval type %q in %s: %w", expr, pkg.PkgPath, evalErr)
+}
+
+// isUndefinedIdent, e.g., undefined: sync
+func isUndefinedIdent(err error) bool {
+	var tyErr types.Error
+	if !errors.As(err, &tyErr) {
+		return false
+	}
+	const prefix = "undefined: "
+	return strings.HasPrefix(tyErr.Msg, prefix) &&
+		!strings.Contains(tyErr.Msg[len(prefix):], ".")
+}
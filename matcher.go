@@ -26,6 +26,22 @@ type (
 		*matchFuns
 		MatchCallEllipsis bool
 		UnparenExpr       bool
+		// MatchConstValue BasicLit pattern matches any constant expression by folded value,
+		// e.g., 1024 matches 1 << 10, KB, time.Second matches 1e9
+		MatchConstValue bool
+		// LexicalLit BasicLit is compared by kind and text, not by value,
+		// e.g., "a" and `a`, 0x10 and 16 are different, takes precedence over MatchConstValue
+		LexicalLit bool
+		// Commutative operands of commutative operators are unordered, comparisons are mirrored,
+		// e.g., a == b matches b == a, a < b matches b > a, string + is still ordered
+		Commutative bool
+		// Normalize equivalent forms, e.g., x := v matches var x = v, see NormalizeMode
+		Normalize NormalizeMode
+		// AlphaEquiv local identifiers are matched by consistent renaming, not by name,
+		// e.g., func(a int) { use(a) } matches func(b int) { use(b) }, see matchAlpha
+		AlphaEquiv bool
+
+		quantifiers []*Quantifier
 	}
 )
 
@@ -34,11 +50,31 @@ func New() *Matcher {
 }
 
 func (m *Matcher) Match(inPkg *Package, pattern, node ast.Node, f Matched) {
-	buildStack := m.mkStackBuilder(node)
-	postOrder(node, func(c *astutil.Cursor) bool {
+	m.walk(inPkg, pattern, node, nil, f)
+}
+
+// MatchIn likes Match, but only subtrees overlapping ranges are traversed,
+// others are skipped, not filtered after matching
+func (m *Matcher) MatchIn(inPkg *Package, ranges LineRanges, pattern, node ast.Node, f Matched) {
+	m.walk(inPkg, pattern, node, mkPreFilter(ranges.mkFilter(inPkg.Fset)), f)
+}
+
+// MatchPruned likes Match, but the subtree of cursor node is skipped, including itself, if prune returns true,
+// prune is called in pre-order, maybe more than once for the same node,
+// e.g., bounded search in combinators, not descending into closures
+func (m *Matcher) MatchPruned(inPkg *Package, pattern, node ast.Node, prune func(*Cursor) bool, f Matched) {
+	m.walk(inPkg, pattern, node, func(c *Cursor) bool { return !prune(c) }, f)
+}
+
+// walk matches all nodes in post-order,
+// subtree is skipped if pre is not nil and returns false
+func (m *Matcher) walk(inPkg *Package, pattern, node ast.Node, pre astutil.ApplyFunc, f Matched) {
+	buildStack := m.mkStackBuilder(node, pre)
+	memo := map[any]any{}
+	astutil.Apply(node, pre, func(c *astutil.Cursor) bool {
 		n := c.Node()
 		stack, names := buildStack(n)
-		mctx := newMCtx(m, inPkg, stack, names)
+		mctx := newMCtx(m, inPkg, stack, names, memo)
 		if m.match(pattern, n, mctx) {
 			f(c, mctx)
 		}
@@ -63,13 +99,13 @@ func (m *Matcher) Matched(inPkg *Package, pattern, rootNode ast.Node) (matched b
 
 type stackBuilder func(node ast.Node) ([]ast.Node, []string)
 
-func (m *Matcher) mkStackBuilder(root ast.Node) stackBuilder {
+func (m *Matcher) mkStackBuilder(root ast.Node, pre astutil.ApplyFunc) stackBuilder {
 	type node struct {
 		node  ast.Node
 		field string
 	}
 	parents := map[ast.Node]node{}
-	postOrder(root, func(c *astutil.Cursor) bool {
+	astutil.Apply(root, pre, func(c *astutil.Cursor) bool {
 		parents[c.Node()] = node{c.Parent(), c.Name()}
 		return true
 	})
@@ -289,6 +325,10 @@ func (m *Matcher) matchStmt(x, y ast.Stmt, ctx *MatchCtx) bool {
 		return matchFun(y, ctx)
 	}
 
+	if m.Normalize != 0 {
+		y = m.normalizeStmt(x, y, ctx)
+	}
+
 	if reflect.TypeOf(x) != reflect.TypeOf(y) {
 		return false
 	}
@@ -482,6 +522,16 @@ func (m *Matcher) matchExpr(x, y ast.Expr, ctx *MatchCtx) bool {
 		return matchFun(y, ctx)
 	}
 
+	if m.Normalize != 0 {
+		y = m.normalizeExpr(x, y, ctx)
+	}
+
+	if lit, ok := x.(*ast.BasicLit); ok && m.MatchConstValue && !m.LexicalLit {
+		if matched, ok := m.matchConstValue(lit, y, ctx); ok {
+			return matched
+		}
+	}
+
 	if reflect.TypeOf(x) != reflect.TypeOf(y) {
 		return false
 	}
@@ -502,7 +552,7 @@ func (m *Matcher) matchExpr(x, y ast.Expr, ctx *MatchCtx) bool {
 		if y == nil {
 			return false
 		}
-		return x.Name == y.Name
+		return m.matchIdentName(x, y, ctx)
 
 	case *ast.Ellipsis:
 		y := y.(*ast.Ellipsis)
@@ -615,6 +665,9 @@ func (m *Matcher) matchExpr(x, y ast.Expr, ctx *MatchCtx) bool {
 		if y == nil {
 			return false
 		}
+		if m.Commutative {
+			return m.matchCommutative(x, y, ctx)
+		}
 		return m.matchToken(x.Op, y.Op, ctx) &&
 			m.matchExpr(x.X, y.X, ctx) &&
 			m.matchExpr(x.Y, y.Y, ctx)
@@ -692,6 +745,13 @@ func (m *Matcher) matchIdent(x, y *ast.Ident, ctx *MatchCtx) bool {
 	if y == nil {
 		return false
 	}
+	return m.matchIdentName(x, y, ctx)
+}
+
+func (m *Matcher) matchIdentName(x, y *ast.Ident, ctx *MatchCtx) bool {
+	if m.AlphaEquiv {
+		return matchAlpha(x, y, ctx)
+	}
 	return x.Name == y.Name
 }
 
@@ -709,9 +769,26 @@ func (m *Matcher) matchBasicLit(x, y *ast.BasicLit, ctx *MatchCtx) bool {
 	// Notice: BasicLit is an atomic Pattern,
 	// &ast.BasicLit{ Kind: token.INT } can be used for matching INT literal
 	// because zero Value is ambiguous, wildcard or zero value?
+	if m.LexicalLit {
+		return x.Kind == y.Kind && x.Value == y.Value
+	}
 	xVal := constant.MakeFromLiteral(x.Value, x.Kind, 0)
 	yVal := constant.MakeFromLiteral(y.Value, y.Kind, 0)
-	return constant.Compare(xVal, token.EQL, yVal)
+	return ConstEqual(xVal, yVal)
+}
+
+// matchConstValue compares literal pattern with folded value of constant expression,
+// ok is false if y is not constant, or type info is absent
+func (m *Matcher) matchConstValue(x *ast.BasicLit, y ast.Expr, ctx *MatchCtx) (matched, ok bool) {
+	if m.tryGetBasicLitMatchFun(x) != nil || IsNilNode(y) || !hasTypeInfo(ctx) {
+		return false, false
+	}
+	tv, found := ctx.TypeInfo().Types[y]
+	if !found || tv.Value == nil {
+		return false, false
+	}
+	xVal := constant.MakeFromLiteral(x.Value, x.Kind, 0)
+	return ConstEqual(xVal, tv.Value), true
 }
 
 func (m *Matcher) matchToken(x, y token.Token, ctx *MatchCtx) bool {
@@ -733,6 +810,12 @@ func (m *Matcher) matchStmts(xs, ys []ast.Stmt, ctx *MatchCtx) bool {
 	if matchFun := m.tryGetStmtsMatchFun(xs); matchFun != nil {
 		return matchFun(StmtsNode(ys), ctx)
 	}
+	if m.Normalize != 0 {
+		xs, ys = m.normalizeStmts(xs, ys, ctx)
+	}
+	if hasQuantifier(m, xs) {
+		return m.matchStmtSeq(toNodes(xs), toNodes(ys), ctx)
+	}
 
 	if len(xs) > 0 {
 		if len(xs)-1 > len(ys) {
@@ -770,6 +853,9 @@ func (m *Matcher) matchExprs(xs, ys []ast.Expr, ctx *MatchCtx) bool {
 	if matchFun := m.tryGetExprsMatchFun(xs); matchFun != nil {
 		return matchFun(ExprsNode(ys), ctx)
 	}
+	if hasQuantifier(m, xs) {
+		return m.matchExprSeq(toNodes(xs), toNodes(ys), ctx)
+	}
 
 	if len(xs) > 0 {
 		if len(xs)-1 > len(ys) {
@@ -0,0 +1,361 @@
+package matcher
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// NormalizeMode equivalent forms of node are normalized to the form of pattern before matching
+// normalized node is made up, and recorded with the original node of the same kind, see MatchCtx.OriginOf,
+// so Bind and StackOf use the original node, TypeOf of equivalent expression is the type of the original one
+// Notice: made-up leaves have no original counterpart, they are bound as-is without type info,
+// e.g., new of new(T), 1 of x += 1, !c of return !c, spec of var x = v
+// so BuiltinCallee(m, "new") doesn't match new(T) made from &T{}
+type NormalizeMode uint
+
+const (
+	NormDefine     NormalizeMode = 1 << iota // x := v ≡ var x = v, all of lhs must be newly declared
+	NormIncDec                               // x++ ≡ x += 1, x-- ≡ x -= 1
+	NormReturnBool                           // if c { return true }; return false ≡ return c, the other side must be return
+	NormNew                                  // &T{} ≡ new(T), T must be struct or array, &M{} of map M is not nil
+	NormDeref                                // (*p).f ≡ p.f
+
+	NormAll = NormDefine | NormIncDec | NormReturnBool | NormNew | NormDeref
+)
+
+func (m *Matcher) normalizeStmt(x, y ast.Stmt, ctx *MatchCtx) ast.Stmt {
+	if IsNilNode(y) {
+		return y
+	}
+	switch x.(type) {
+	case *ast.AssignStmt:
+		switch y := y.(type) {
+		case *ast.DeclStmt:
+			if m.Normalize&NormDefine != 0 {
+				if s := varDeclToDefine(y); s != nil {
+					ctx.madeUp(y, false, s)
+					return s
+				}
+			}
+		case *ast.IncDecStmt:
+			if m.Normalize&NormIncDec != 0 {
+				tok := token.ADD_ASSIGN
+				if y.Tok == token.DEC {
+					tok = token.SUB_ASSIGN
+				}
+				one := &ast.BasicLit{ValuePos: y.TokPos, Kind: token.INT, Value: "1"}
+				s := &ast.AssignStmt{
+					Lhs:    []ast.Expr{y.X},
+					TokPos: y.TokPos,
+					Tok:    tok,
+					Rhs:    []ast.Expr{one},
+				}
+				ctx.madeUp(y, false, s)
+				return s
+			}
+		}
+	case *ast.DeclStmt:
+		if y, ok := y.(*ast.AssignStmt); ok && m.Normalize&NormDefine != 0 {
+			if s := defineToVarDecl(ctx, y); s != nil {
+				ctx.madeUp(y, false, s)
+				return s
+			}
+		}
+	case *ast.IncDecStmt:
+		if y, ok := y.(*ast.AssignStmt); ok && m.Normalize&NormIncDec != 0 {
+			if s := assignToIncDec(ctx, y); s != nil {
+				ctx.madeUp(y, false, s)
+				return s
+			}
+		}
+	}
+	return y
+}
+
+func (m *Matcher) normalizeExpr(x, y ast.Expr, ctx *MatchCtx) ast.Expr {
+	if IsNilNode(y) {
+		return y
+	}
+	switch x := x.(type) {
+	case *ast.CallExpr:
+		if y, ok := y.(*ast.UnaryExpr); ok && m.Normalize&NormNew != 0 {
+			if lit := zeroCompositeLit(ctx, y); lit != nil {
+				fun := &ast.Ident{NamePos: y.OpPos, Name: "new"}
+				call := &ast.CallExpr{
+					Fun:    fun,
+					Lparen: lit.Lbrace,
+					Args:   []ast.Expr{lit.Type},
+					Rparen: lit.Rbrace,
+				}
+				ctx.madeUp(y, true, call)
+				return call
+			}
+		}
+	case *ast.UnaryExpr:
+		if y, ok := y.(*ast.CallExpr); ok && x.Op == token.AND && m.Normalize&NormNew != 0 {
+			if isBuiltinNew(ctx, y) {
+				lit := &ast.CompositeLit{
+					Type:   y.Args[0],
+					Lbrace: y.Lparen,
+					Rbrace: y.Rparen,
+				}
+				addr := &ast.UnaryExpr{OpPos: y.Pos(), Op: token.AND, X: lit}
+				ctx.madeUp(y, true, addr)
+				return addr
+			}
+		}
+	case *ast.SelectorExpr:
+		if y, ok := y.(*ast.SelectorExpr); ok && m.Normalize&NormDeref != 0 {
+			_, xStar := astutil.Unparen(x.X).(*ast.StarExpr)
+			yStar, _ := astutil.Unparen(y.X).(*ast.StarExpr)
+			switch {
+			case xStar && yStar == nil && isPointer(ctx, y.X):
+				star := &ast.StarExpr{Star: y.Pos(), X: y.X}
+				paren := &ast.ParenExpr{Lparen: y.Pos(), X: star, Rparen: y.X.End()}
+				sel := &ast.SelectorExpr{X: paren, Sel: y.Sel}
+				ctx.madeUp(y, true, sel)
+				return sel
+			case !xStar && yStar != nil:
+				sel := &ast.SelectorExpr{X: yStar.X, Sel: y.Sel}
+				ctx.madeUp(y, true, sel)
+				return sel
+			}
+		}
+	}
+	return y
+}
+
+// normalizeStmts if c { return true }; return false => return c
+// only if the other side is a return at the same position,
+// e.g., pattern of if stmt followed by return false matches the code as-is
+// Notice: positions after quantifier or rest pattern are unknown, not normalized
+func (m *Matcher) normalizeStmts(xs, ys []ast.Stmt, ctx *MatchCtx) ([]ast.Stmt, []ast.Stmt) {
+	if m.Normalize&NormReturnBool == 0 {
+		return xs, ys
+	}
+	var (
+		nxs, nys []ast.Stmt
+		changed  bool
+		i, j     int
+	)
+	for ; i < len(xs) && j < len(ys); i, j = i+1, j+1 {
+		x, y := xs[i], ys[j]
+		if m.tryGetQuantifier(x) != nil || m.tryGetRestStmtMatchFun(x) != nil {
+			break
+		}
+		if isReturn(x) && j+1 < len(ys) {
+			if ret := collapseReturnBool(ctx, y, ys[j+1]); ret != nil {
+				y, j, changed = ret, j+1, true
+			}
+		} else if isReturn(y) && i+1 < len(xs) {
+			if ret := collapseReturnBool(ctx, x, xs[i+1]); ret != nil {
+				x, i, changed = ret, i+1, true
+			}
+		}
+		nxs, nys = append(nxs, x), append(nys, y)
+	}
+	if !changed {
+		return xs, ys
+	}
+	return append(nxs, xs[i:]...), append(nys, ys[j:]...)
+}
+
+func isReturn(s ast.Stmt) bool {
+	_, ok := s.(*ast.ReturnStmt)
+	return ok
+}
+
+func collapseReturnBool(ctx *MatchCtx, s1, s2 ast.Stmt) *ast.ReturnStmt {
+	ifStmt, _ := s1.(*ast.IfStmt)
+	ret, _ := s2.(*ast.ReturnStmt)
+	if ifStmt == nil || ret == nil || ifStmt.Init != nil || ifStmt.Else != nil ||
+		ifStmt.Body == nil || len(ifStmt.Body.List) != 1 {
+		return nil
+	}
+	thenRet, _ := ifStmt.Body.List[0].(*ast.ReturnStmt)
+	if thenRet == nil {
+		return nil
+	}
+	thenVal, ok1 := boolIdent(thenRet)
+	elseVal, ok2 := boolIdent(ret)
+	if !ok1 || !ok2 || thenVal == elseVal {
+		return nil
+	}
+	cond := ifStmt.Cond
+	if !thenVal {
+		cond = &ast.UnaryExpr{OpPos: cond.Pos(), Op: token.NOT, X: cond}
+	}
+	ret = &ast.ReturnStmt{Return: ifStmt.If, Results: []ast.Expr{cond}}
+	ctx.madeUp(ifStmt, false, ret)
+	return ret
+}
+
+func boolIdent(ret *ast.ReturnStmt) (val, ok bool) {
+	if len(ret.Results) != 1 {
+		return false, false
+	}
+	id, _ := ret.Results[0].(*ast.Ident)
+	if id == nil || id.Name != "true" && id.Name != "false" {
+		return false, false
+	}
+	return id.Name == "true", true
+}
+
+// varDeclToDefine var x, y = v1, v2 => x, y := v1, v2
+func varDeclToDefine(y *ast.DeclStmt) *ast.AssignStmt {
+	decl, _ := y.Decl.(*ast.GenDecl)
+	if decl == nil || decl.Tok != token.VAR || len(decl.Specs) != 1 {
+		return nil
+	}
+	spec := decl.Specs[0].(*ast.ValueSpec)
+	if spec.Type != nil || len(spec.Values) == 0 {
+		return nil
+	}
+	lhs := make([]ast.Expr, len(spec.Names))
+	for i, name := range spec.Names {
+		lhs[i] = name
+	}
+	return &ast.AssignStmt{
+		Lhs:    lhs,
+		TokPos: decl.TokPos,
+		Tok:    token.DEFINE,
+		Rhs:    spec.Values,
+	}
+}
+
+// defineToVarDecl x, y := v1, v2 => var x, y = v1, v2
+// only if all of lhs are newly declared, e.g., err of a, err := f() may be reused
+func defineToVarDecl(ctx *MatchCtx, y *ast.AssignStmt) *ast.DeclStmt {
+	if y.Tok != token.DEFINE || !hasTypeInfo(ctx) {
+		return nil
+	}
+	names := make([]*ast.Ident, len(y.Lhs))
+	for i, lhs := range y.Lhs {
+		id, _ := lhs.(*ast.Ident)
+		if id == nil || ctx.TypeInfo().Defs[id] == nil {
+			return nil
+		}
+		names[i] = id
+	}
+	return &ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			TokPos: y.Pos(),
+			Tok:    token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{Names: names, Values: y.Rhs},
+			},
+		},
+	}
+}
+
+// assignToIncDec x += 1 => x++
+func assignToIncDec(ctx *MatchCtx, y *ast.AssignStmt) *ast.IncDecStmt {
+	if len(y.Lhs) != 1 || len(y.Rhs) != 1 {
+		return nil
+	}
+	var tok token.Token
+	switch y.Tok {
+	case token.ADD_ASSIGN:
+		tok = token.INC
+	case token.SUB_ASSIGN:
+		tok = token.DEC
+	default:
+		return nil
+	}
+	var val constant.Value
+	if lit, ok := y.Rhs[0].(*ast.BasicLit); ok {
+		val = constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+	} else if hasTypeInfo(ctx) {
+		val = ctx.TypeInfo().Types[y.Rhs[0]].Value
+	}
+	if !ConstEqual(val, constant.MakeInt64(1)) {
+		return nil
+	}
+	return &ast.IncDecStmt{X: y.Lhs[0], TokPos: y.TokPos, Tok: tok}
+}
+
+// zeroCompositeLit &T{}, T is struct or array
+func zeroCompositeLit(ctx *MatchCtx, y *ast.UnaryExpr) *ast.CompositeLit {
+	if y.Op != token.AND {
+		return nil
+	}
+	lit, _ := astutil.Unparen(y.X).(*ast.CompositeLit)
+	if lit == nil || lit.Type == nil || len(lit.Elts) != 0 || !isStructOrArray(ctx, lit) {
+		return nil
+	}
+	return lit
+}
+
+// isBuiltinNew new(T), T is struct or array
+func isBuiltinNew(ctx *MatchCtx, y *ast.CallExpr) bool {
+	id, _ := astutil.Unparen(y.Fun).(*ast.Ident)
+	if id == nil || id.Name != "new" || len(y.Args) != 1 || !hasTypeInfo(ctx) {
+		return false
+	}
+	if _, ok := ctx.ObjectOf(id).(*types.Builtin); !ok {
+		return false
+	}
+	return isStructOrArray(ctx, y.Args[0])
+}
+
+// isStructOrArray zero value of struct or array is not nil, unlike map, slice, etc.
+func isStructOrArray(ctx *MatchCtx, x ast.Expr) bool {
+	if !hasTypeInfo(ctx) {
+		return false
+	}
+	ty := ctx.TypeOf(x)
+	if ty == nil {
+		return false
+	}
+	switch ty.Underlying().(type) {
+	case *types.Struct, *types.Array:
+		return true
+	}
+	return false
+}
+
+func isPointer(ctx *MatchCtx, x ast.Expr) bool {
+	if !hasTypeInfo(ctx) {
+		return false
+	}
+	ty := ctx.TypeOf(x)
+	if ty == nil {
+		return false
+	}
+	_, ok := ty.Underlying().(*types.Pointer)
+	return ok
+}
+
+func hasTypeInfo(ctx *MatchCtx) bool {
+	return ctx.Pkg != nil && ctx.TypeInfo() != nil
+}
+
+type (
+	madeUpKey  struct{}
+	madeUpNode struct {
+		origin ast.Node
+		equiv  bool // same value as origin, e.g., new(T) of &T{}
+	}
+)
+
+// madeUp records made-up node of normalization with its original counterpart,
+// both of them must be the same kind, i.e., stmt of stmt, expr of expr,
+// made-up leaves are not recorded, e.g., 1 of x += 1 is not the original x++
+func (c *MatchCtx) madeUp(origin ast.Node, equiv bool, n ast.Node) {
+	made := c.madeUpNodes()
+	if o, ok := made[origin]; ok {
+		// made up from made-up node
+		origin, equiv = o.origin, equiv && o.equiv
+	}
+	made[n] = madeUpNode{origin, equiv}
+}
+
+func (c *MatchCtx) madeUpNodes() map[ast.Node]madeUpNode {
+	return c.Memo(madeUpKey{}, func() any {
+		return map[ast.Node]madeUpNode{}
+	}).(map[ast.Node]madeUpNode)
+}
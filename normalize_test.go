@@ -0,0 +1,148 @@
+package matcher_test
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/goghcrow/go-matcher"
+	. "github.com/goghcrow/go-matcher/combinator"
+)
+
+func TestNormalizeBinds(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		src  string
+		ptn  func(m *matcher.Matcher) ast.Node
+		want []map[string]string // variable -> type of bound node, of each match
+	}{
+		{
+			name: "inc",
+			src:  `package p; func f() { x := 0; x++ }`,
+			ptn: func(m *matcher.Matcher) ast.Node {
+				return Bind(m, "s", matcher.PatternOf[StmtPattern](m, &ast.AssignStmt{
+					Lhs: []ast.Expr{matcher.MkVar[ExprPattern](m, "x")},
+					Tok: token.ADD_ASSIGN,
+					Rhs: []ast.Expr{matcher.MkVar[ExprPattern](m, "v")},
+				}))
+			},
+			want: []map[string]string{{"s": "*ast.IncDecStmt", "x": "*ast.Ident", "v": "*ast.BasicLit"}},
+		},
+		{
+			name: "return bool",
+			src:  `package p; func f(b bool) bool { if b { return false }; return true }`,
+			ptn: func(m *matcher.Matcher) ast.Node {
+				return &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{
+					Results: []ast.Expr{matcher.MkVar[ExprPattern](m, "c")},
+				}}}
+			},
+			want: []map[string]string{
+				// then block of if
+				{"c": "*ast.Ident"},
+				{"c": "*ast.UnaryExpr"},
+			},
+		},
+		{
+			name: "define",
+			src:  `package p; func f() { x := 0; _ = x }`,
+			ptn: func(m *matcher.Matcher) ast.Node {
+				return Bind(m, "s", matcher.PatternOf[StmtPattern](m, &ast.DeclStmt{
+					Decl: matcher.MkVar[DeclPattern](m, "d"),
+				}))
+			},
+			want: []map[string]string{{"s": "*ast.AssignStmt", "d": "*ast.GenDecl"}},
+		},
+		{
+			name: "new",
+			src:  `package p; type T struct{}; var _ = &T{}`,
+			ptn: func(m *matcher.Matcher) ast.Node {
+				return Bind(m, "e", matcher.PatternOf[ExprPattern](m, &ast.CallExpr{
+					Fun:  matcher.MkVar[ExprPattern](m, "fun"),
+					Args: []ast.Expr{matcher.MkVar[ExprPattern](m, "t")},
+				}))
+			},
+			want: []map[string]string{{"e": "*ast.UnaryExpr", "fun": "*ast.Ident", "t": "*ast.Ident"}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := loadSrc(t, tt.src)
+			m := matcher.New()
+			m.Normalize = matcher.NormAll
+			var got []map[string]string
+			m.Match(pkg, tt.ptn(m), pkg.Syntax[0], func(c *matcher.Cursor, ctx *matcher.MatchCtx) {
+				types := map[string]string{}
+				for name, n := range ctx.Binds {
+					types[name] = reflect.TypeOf(n).String()
+				}
+				got = append(got, types)
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormReturnBool(t *testing.T) {
+	const ifReturn = `package p; func f(b bool) bool { if b { return true }; return false }`
+	ret := func(name string) *ast.ReturnStmt {
+		return &ast.ReturnStmt{Results: []ast.Expr{&ast.Ident{Name: name}}}
+	}
+	for _, tt := range []struct {
+		name    string
+		src     string
+		ptn     func(m *matcher.Matcher) ast.Node
+		want    int // without normalization
+		wantAll int // with NormAll
+	}{
+		{
+			// then block is matched anyway
+			name: "collapse code",
+			src:  ifReturn,
+			ptn: func(m *matcher.Matcher) ast.Node {
+				return &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{}}}
+			},
+			want:    1,
+			wantAll: 2,
+		},
+		{
+			// the pattern is not a return at the position of if
+			name: "not collapse code",
+			src:  ifReturn,
+			ptn: func(m *matcher.Matcher) ast.Node {
+				return &ast.BlockStmt{List: []ast.Stmt{
+					Bind(m, "if", matcher.PatternOf[StmtPattern](m, &ast.IfStmt{})),
+					ret("false"),
+				}}
+			},
+			want:    1,
+			wantAll: 1,
+		},
+		{
+			name: "collapse pattern",
+			src:  `package p; func f(b bool) bool { return b }`,
+			ptn: func(m *matcher.Matcher) ast.Node {
+				return &ast.BlockStmt{List: []ast.Stmt{
+					&ast.IfStmt{Cond: &ast.Ident{Name: "b"}, Body: &ast.BlockStmt{List: []ast.Stmt{ret("true")}}},
+					ret("false"),
+				}}
+			},
+			want:    0,
+			wantAll: 1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := loadSrc(t, tt.src)
+			m := matcher.New()
+			if got := matchedCount(m, pkg, tt.ptn(m)); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+			m = matcher.New()
+			m.Normalize = matcher.NormAll
+			if got := matchedCount(m, pkg, tt.ptn(m)); got != tt.wantAll {
+				t.Fatalf("NormAll: got %d, want %d", got, tt.wantAll)
+			}
+		})
+	}
+}
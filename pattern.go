@@ -69,10 +69,10 @@ func IsPattern[T Pattern](m *Matcher, n any) bool {
 
 // ↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓ Factory ↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓
 
-// MkVar make variable for binding matched Node
+// MkVar make variable for binding matched Node, made-up node of normalization is bound as its original node
 func MkVar[T Pattern](m *Matcher, name string) T {
 	return MkPattern[T](m, func(n ast.Node, ctx *MatchCtx) bool {
-		ctx.Binds[name] = n
+		ctx.Binds[name] = ctx.OriginOf(n)
 		return true
 	})
 }
@@ -12,7 +12,7 @@ import (
 // ↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓ Pseudo Node ↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓↓
 
 type PseudoNode interface {
-	FunNode | StmtsNode | ExprsNode | SpecsNode | IdentsNode | FieldsNode | TokenNode
+	FunNode | StmtsNode | ExprsNode | SpecsNode | IdentsNode | FieldsNode | TokenNode | NodesNode
 }
 
 type (
@@ -23,6 +23,7 @@ type (
 	IdentsNode []*ast.Ident // for the callback param of IdentsPattern
 	FieldsNode []*ast.Field // for the callback param of FieldsPattern
 	TokenNode  token.Token  // for the callback param of TokenPattern
+	NodesNode  []ast.Node   // for the bind of all matched nodes, e.g., AnyBind with BindAll
 )
 
 func (FunNode) Pos() token.Pos    { return token.NoPos }
@@ -39,6 +40,8 @@ func (FieldsNode) Pos() token.Pos { return token.NoPos }
 func (FieldsNode) End() token.Pos { return token.NoPos }
 func (TokenNode) Pos() token.Pos  { return token.NoPos }
 func (TokenNode) End() token.Pos  { return token.NoPos }
+func (NodesNode) Pos() token.Pos  { return token.NoPos }
+func (NodesNode) End() token.Pos  { return token.NoPos }
 
 func IsPseudoNode(n ast.Node) bool {
 	switch n.(type) {
@@ -56,6 +59,8 @@ func IsPseudoNode(n ast.Node) bool {
 		return true
 	case TokenNode:
 		return true
+	case NodesNode:
+		return true
 	}
 	return false
 }
@@ -96,6 +101,12 @@ func showPseudoNode(fset *token.FileSet, n ast.Node) string {
 		return strings.Join(xs, "\n")
 	case TokenNode:
 		return token.Token(n).String()
+	case NodesNode:
+		xs := make([]string, len(n))
+		for i, it := range n {
+			xs[i] = ShowNode(fset, it)
+		}
+		return strings.Join(xs, "\n")
 	default:
 		panic("unknown pseudo node")
 	}
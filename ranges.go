@@ -0,0 +1,83 @@
+package matcher
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	// LineRange closed interval of 1-based line numbers
+	LineRange struct {
+		Start, End int
+	}
+	// LineRanges filename -> line ranges
+	// filename can be relative, e.g., path in the unified diff,
+	// it's matched with the suffix of the filename in token.FileSet
+	LineRanges map[string][]LineRange
+)
+
+// Add lines [start, end] of file, adjacent ranges are merged
+func (r LineRanges) Add(filename string, start, end int) {
+	assert(start <= end, "invalid line range")
+	filename = filepath.ToSlash(filename)
+	xs := r[filename]
+	if n := len(xs); n > 0 && xs[n-1].End+1 >= start && xs[n-1].Start <= start {
+		if end > xs[n-1].End {
+			xs[n-1].End = end
+		}
+		return
+	}
+	r[filename] = append(xs, LineRange{start, end})
+}
+
+// Lookup ranges of filename, by exactly name or path suffix
+func (r LineRanges) Lookup(filename string) []LineRange {
+	filename = filepath.ToSlash(filename)
+	if xs, ok := r[filename]; ok {
+		return xs
+	}
+	var found []LineRange
+	for name, xs := range r {
+		if strings.HasSuffix(filename, "/"+strings.TrimPrefix(name, "./")) {
+			found = append(found, xs...)
+		}
+	}
+	return found
+}
+
+// Overlaps reports whether lines of n overlap the ranges
+// node without valid position is treated as overlapping, because we can't tell
+func (r LineRanges) Overlaps(fset *token.FileSet, n ast.Node) bool {
+	return r.mkFilter(fset)(n)
+}
+
+func (r LineRanges) mkFilter(fset *token.FileSet) func(ast.Node) bool {
+	cache := map[*token.File][]LineRange{}
+	return func(n ast.Node) bool {
+		if IsNilNode(n) || IsPseudoNode(n) || !n.Pos().IsValid() {
+			return true
+		}
+		f := fset.File(n.Pos())
+		if f == nil {
+			return true
+		}
+		xs, ok := cache[f]
+		if !ok {
+			xs = r.Lookup(f.Name())
+			cache[f] = xs
+		}
+		start := f.Line(n.Pos())
+		end := start
+		if p := n.End(); p.IsValid() && int(p) <= f.Base()+f.Size() {
+			end = f.Line(p)
+		}
+		for _, it := range xs {
+			if it.Start <= end && start <= it.End {
+				return true
+			}
+		}
+		return false
+	}
+}
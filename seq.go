@@ -0,0 +1,242 @@
+package matcher
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+)
+
+// Regex-style quantifier over elements of StmtsPattern and ExprsPattern
+// Quantifier is encoded as (BadStmt|BadExpr).To, index of Matcher.quantifiers
+// (BadStmt|BadExpr).From is the MatchFun for single element, e.g., IfStmt.Body
+// e.g. any number of logging calls followed by a return
+// []ast.Stmt{ ZeroOrMore[StmtPattern](m, logStmt), &ast.ReturnStmt{} }
+
+type (
+	SeqElemPattern interface {
+		StmtPattern | ExprPattern
+	}
+	// Quantifier matches Min to Max repetitions of any alternative sequence, Max < 0 means unbounded
+	// Notice: binds in repetitions are collected as NodesNode if Max != 1, e.g., ZeroOrMore
+	Quantifier struct {
+		Alts     [][]ast.Node
+		Min, Max int
+	}
+)
+
+// MkSeqPattern make quantifier element of StmtsPattern or ExprsPattern,
+// elements of Alts must be ast.Stmt for StmtPattern, ast.Expr for ExprPattern
+func MkSeqPattern[T SeqElemPattern](m *Matcher, q *Quantifier) T {
+	assert(len(q.Alts) > 0, "empty quantifier")
+	assert(q.Max < 0 || q.Min <= q.Max, "invalid quantifier range")
+
+	m.quantifiers = append(m.quantifiers, q)
+	to := token.Pos(-len(m.quantifiers))
+
+	var zero T
+	switch any(zero).(type) {
+	case StmtPattern:
+		for _, alt := range q.Alts {
+			for _, it := range alt {
+				_, ok := it.(ast.Stmt)
+				assert(ok, "invalid quantifier: stmt expected")
+			}
+		}
+		x := &ast.BadStmt{To: to}
+		x.From = m.append(func(n ast.Node, ctx *MatchCtx) bool {
+			return m.matchStmtSeq([]ast.Node{x}, []ast.Node{n}, ctx)
+		})
+		return any(x).(T)
+	case ExprPattern:
+		for _, alt := range q.Alts {
+			for _, it := range alt {
+				_, ok := it.(ast.Expr)
+				assert(ok, "invalid quantifier: expr expected")
+			}
+		}
+		x := &ast.BadExpr{To: to}
+		x.From = m.append(func(n ast.Node, ctx *MatchCtx) bool {
+			return m.matchExprSeq([]ast.Node{x}, []ast.Node{n}, ctx)
+		})
+		return any(x).(T)
+	default:
+		panic("unreachable")
+	}
+}
+
+func (m *Matcher) tryGetQuantifier(n ast.Node) *Quantifier {
+	switch x := n.(type) {
+	case *ast.BadStmt:
+		if x != nil && x.From < 0 && x.To < 0 {
+			return m.quantifiers[-x.To-1]
+		}
+	case *ast.BadExpr:
+		if x != nil && x.From < 0 && x.To < 0 {
+			return m.quantifiers[-x.To-1]
+		}
+	}
+	return nil
+}
+
+func hasQuantifier[T ast.Node](m *Matcher, xs []T) bool {
+	for _, x := range xs {
+		if m.tryGetQuantifier(x) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func toNodes[T ast.Node](xs []T) []ast.Node {
+	ns := make([]ast.Node, len(xs))
+	for i, x := range xs {
+		ns[i] = x
+	}
+	return ns
+}
+
+func (m *Matcher) matchStmtSeq(xs, ys []ast.Node, ctx *MatchCtx) bool {
+	s := &seqMatcher{
+		m: m,
+		elem: func(x, y ast.Node, ctx *MatchCtx) bool {
+			return m.matchStmt(x.(ast.Stmt), y.(ast.Stmt), ctx)
+		},
+		rest: func(x ast.Node, ys []ast.Node, ctx *MatchCtx) (matched, ok bool) {
+			f := m.tryGetRestStmtMatchFun(x.(ast.Stmt))
+			if f == nil {
+				return false, false
+			}
+			stmts := make(StmtsNode, len(ys))
+			for i, y := range ys {
+				stmts[i] = y.(ast.Stmt)
+			}
+			return f(stmts, ctx), true
+		},
+	}
+	return s.matchAll(xs, ys, ctx)
+}
+
+func (m *Matcher) matchExprSeq(xs, ys []ast.Node, ctx *MatchCtx) bool {
+	s := &seqMatcher{
+		m: m,
+		elem: func(x, y ast.Node, ctx *MatchCtx) bool {
+			return m.matchExpr(x.(ast.Expr), y.(ast.Expr), ctx)
+		},
+		rest: func(x ast.Node, ys []ast.Node, ctx *MatchCtx) (matched, ok bool) {
+			f := m.tryGetRestExprMatchFun(x.(ast.Expr))
+			if f == nil {
+				return false, false
+			}
+			exprs := make(ExprsNode, len(ys))
+			for i, y := range ys {
+				exprs[i] = y.(ast.Expr)
+			}
+			return f(exprs, ctx), true
+		},
+	}
+	return s.matchAll(xs, ys, ctx)
+}
+
+// seqMatcher backtracking matcher in continuation-passing style,
+// k is called with the rest of ys and ctx after matching xs
+type (
+	seqCont    func(ys []ast.Node, ctx *MatchCtx) bool
+	seqMatcher struct {
+		m    *Matcher
+		elem func(x, y ast.Node, ctx *MatchCtx) bool
+		rest func(x ast.Node, ys []ast.Node, ctx *MatchCtx) (matched, ok bool)
+	}
+)
+
+func (s *seqMatcher) matchAll(xs, ys []ast.Node, ctx *MatchCtx) bool {
+	return s.match(xs, ys, ctx, func(ys []ast.Node, ctx *MatchCtx) bool {
+		return len(ys) == 0
+	})
+}
+
+func (s *seqMatcher) match(xs, ys []ast.Node, ctx *MatchCtx, k seqCont) bool {
+	if len(xs) == 0 {
+		return k(ys, ctx)
+	}
+
+	x := xs[0]
+	if q := s.m.tryGetQuantifier(x); q != nil {
+		return s.repeat(q, xs[1:], ys, ctx, nil, k)
+	}
+
+	clone := ctx.Clone()
+	if len(xs) == 1 {
+		// the last rest pattern consumes all
+		if matched, ok := s.rest(x, ys, clone); ok {
+			if matched && k(nil, clone) {
+				ctx.Commit(clone)
+				return true
+			}
+			return false
+		}
+	}
+
+	if len(ys) == 0 {
+		return false
+	}
+	if s.elem(x, ys[0], clone) && s.match(xs[1:], ys[1:], clone, k) {
+		ctx.Commit(clone)
+		return true
+	}
+	return false
+}
+
+// repeat matches q greedily, then xs, reps are binds of matched repetitions
+func (s *seqMatcher) repeat(q *Quantifier, xs, ys []ast.Node, ctx *MatchCtx, reps []Binds, k seqCont) bool {
+	if q.Max < 0 || len(reps) < q.Max {
+		for _, alt := range q.Alts {
+			clone := ctx.Clone()
+			matched := s.match(alt, ys, clone, func(rest []ast.Node, repCtx *MatchCtx) bool {
+				// empty repetition is useless once Min reached, and may loop infinitely
+				if len(rest) == len(ys) && len(reps) >= q.Min {
+					return false
+				}
+				rep := Binds{}
+				for name, n := range repCtx.Binds {
+					if old, ok := ctx.Binds[name]; !ok || !sameNode(old, n) {
+						rep[name] = n
+					}
+				}
+				return s.repeat(q, xs, rest, repCtx, append(reps[:len(reps):len(reps)], rep), k)
+			})
+			if matched {
+				ctx.Commit(clone)
+				return true
+			}
+		}
+	}
+
+	if len(reps) < q.Min {
+		return false
+	}
+	clone := ctx.Clone()
+	if q.Max != 1 {
+		lists := map[PatternVar]NodesNode{}
+		for _, rep := range reps {
+			for name, n := range rep {
+				lists[name] = append(lists[name], n)
+			}
+		}
+		for name, ns := range lists {
+			clone.Binds[name] = ns
+		}
+	}
+	if s.match(xs, ys, clone, k) {
+		ctx.Commit(clone)
+		return true
+	}
+	return false
+}
+
+// sameNode pseudo node may be uncomparable, e.g., NodesNode
+func sameNode(x, y ast.Node) bool {
+	if IsPseudoNode(x) || IsPseudoNode(y) {
+		return reflect.DeepEqual(x, y)
+	}
+	return x == y
+}
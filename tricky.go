@@ -8,6 +8,7 @@ import (
 // MatchFun Container
 // Index ref MkXXXPattern
 // index: (BadExpr|BadStmt|BadDecl).FromPos
+// quantifier: (BadExpr|BadStmt).ToPos, negative index of Matcher.quantifiers, see MkSeqPattern, tryGetQuantifier
 // ImportSpec.EndPos
 // Ident.NamePos
 // Field.Doc.List[0].Slash